@@ -14,6 +14,7 @@ func main() {
 		os.Args,
 		slog.LevelWarn,
 		k8s.NewK8S,
+		os.Getenv("PATH"),
 		version.CheckForUpdate,
 		os.Stdout,
 		os.Stderr,
@@ -0,0 +1,189 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	kubesleep "github.com/Y0-L0/kubesleep/kubesleep"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// annotationRule suspends every resource of a kind by setting an annotation its operator reacts to.
+type annotationRule struct {
+	gvk        schema.GroupVersionKind
+	annotation string
+	asleep     string
+	// timestamp rules only check for the presence of the annotation. The value is set to the time of suspension.
+	timestamp bool
+	// pods is the number of pods a running resource owns. They count towards the suspended pods.
+	pods int32
+}
+
+// annotationRules are the built-in operator integrations. Rules for kinds that are not installed are skipped.
+var annotationRules = []annotationRule{
+	// KEDA scales the target of a paused ScaledObject to the given replica count and ignores its triggers.
+	{
+		gvk:        schema.GroupVersionKind{Group: "keda.sh", Version: "v1alpha1", Kind: "ScaledObject"},
+		annotation: "autoscaling.keda.sh/paused-replicas",
+		asleep:     "0",
+	},
+	// ScaledJobs have no replicas to pin. KEDA stops creating Jobs for them while they are paused.
+	{
+		gvk:        schema.GroupVersionKind{Group: "keda.sh", Version: "v1alpha1", Kind: "ScaledJob"},
+		annotation: "autoscaling.keda.sh/paused",
+		asleep:     "true",
+	},
+	// The Kubeflow notebook controller scales the StatefulSet of a stopped Notebook to zero.
+	{
+		gvk:        schema.GroupVersionKind{Group: "kubeflow.org", Version: "v1", Kind: "Notebook"},
+		annotation: "kubeflow-resource-stopped",
+		timestamp:  true,
+		pods:       1,
+	},
+}
+
+// annotationState records the original value of the annotation. A nil Value means it did not exist.
+type annotationState struct {
+	Annotation string  `json:"annotation"`
+	Value      *string `json:"value,omitempty"`
+}
+
+func (k8s K8Simpl) getAnnotationSuspendables(ctx context.Context, namespace string) (map[string]kubesleep.Suspendable, error) {
+	suspendables := map[string]kubesleep.Suspendable{}
+
+	for _, rule := range annotationRules {
+		mapping, err := k8s.mapper.RESTMapping(rule.gvk.GroupKind(), rule.gvk.Version)
+		if meta.IsNoMatchError(err) {
+			slog.Debug("Kind is not installed; skipping its integration", "gvk", rule.gvk)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		objects, err := k8s.dynamic.Resource(mapping.Resource).
+			Namespace(namespace).
+			List(ctx, metav1.ListOptions{})
+		if apierrors.IsForbidden(err) {
+			slog.Warn("Missing permissions to list resource; skipping it", "resource", mapping.Resource, "namespace", namespace)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		for _, object := range objects.Items {
+			state := annotationState{Annotation: rule.annotation}
+			if value, found := object.GetAnnotations()[rule.annotation]; found {
+				state.Value = &value
+			}
+			data, err := json.Marshal(state)
+			if err != nil {
+				return nil, err
+			}
+
+			var suspend func(context.Context) error
+			var replicas int32
+			if rule.isAsleep(state.Value) {
+				suspend = k8s.noopSuspendAnnotation(namespace, rule.gvk.Kind, object.GetName())
+			} else {
+				suspend = k8s.suspendAnnotation(namespace, mapping.Resource, object.GetName(), rule)
+				replicas = rule.pods
+			}
+
+			s := kubesleep.NewSuspendable(
+				kubesleep.Annotated,
+				object.GetName(),
+				replicas,
+				suspend,
+			).WithResource(rule.gvk.GroupVersion().String(), rule.gvk.Kind).WithState(data)
+			slog.Debug("parsed Suspendable", "Suspendable", s, "namespace", namespace)
+			suspendables[s.Identifier()] = s
+		}
+	}
+
+	return suspendables, nil
+}
+
+// isAsleep reports whether the current annotation value already suspends the resource.
+func (r annotationRule) isAsleep(value *string) bool {
+	if value == nil {
+		return false
+	}
+	return r.timestamp || *value == r.asleep
+}
+
+func (r annotationRule) asleepValue() string {
+	if r.timestamp {
+		return time.Now().UTC().Format(time.RFC3339)
+	}
+	return r.asleep
+}
+
+func (k8s K8Simpl) noopSuspendAnnotation(namespace, kind, name string) func(context.Context) error {
+	return func(ctx context.Context) error {
+		slog.Debug("Resource already carries its suspend annotation; skipping suspend", "namespace", namespace, "kind", kind, "name", name)
+		return nil
+	}
+}
+
+func (k8s K8Simpl) suspendAnnotation(namespace string, resource schema.GroupVersionResource, name string, rule annotationRule) func(context.Context) error {
+	return func(ctx context.Context) error {
+		value := rule.asleepValue()
+		if err := k8s.patchAnnotation(ctx, namespace, resource, name, rule.annotation, &value); err != nil {
+			return err
+		}
+		slog.Info("Suspended resource by annotation", "resource", resource, "name", name, "namespace", namespace, "annotation", rule.annotation)
+		return nil
+	}
+}
+
+func (k8s K8Simpl) wakeAnnotation(ctx context.Context, namespace string, suspendable kubesleep.Suspendable) error {
+	var state annotationState
+	if err := json.Unmarshal(suspendable.State(), &state); err != nil {
+		return fmt.Errorf("invalid annotation state: %w", err)
+	}
+
+	gvk := schema.FromAPIVersionAndKind(suspendable.APIVersion(), suspendable.Kind())
+	mapping, err := k8s.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return err
+	}
+
+	if err := k8s.patchAnnotation(ctx, namespace, mapping.Resource, suspendable.Name(), state.Annotation, state.Value); err != nil {
+		return err
+	}
+	slog.Info("Woke up resource by annotation", "resource", mapping.Resource, "name", suspendable.Name(), "namespace", namespace, "annotation", state.Annotation)
+	return nil
+}
+
+// patchAnnotation sets an annotation or removes it if value is nil.
+func (k8s K8Simpl) patchAnnotation(ctx context.Context, namespace string, resource schema.GroupVersionResource, name, annotation string, value *string) error {
+	patch, err := json.Marshal(map[string]any{
+		"metadata": map[string]any{
+			"annotations": map[string]*string{annotation: value},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = k8s.dynamic.Resource(resource).Namespace(namespace).Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{})
+	return err
+}
+
+func hasAnnotationRule(groupKind schema.GroupKind) bool {
+	for _, rule := range annotationRules {
+		if rule.gvk.GroupKind() == groupKind {
+			return true
+		}
+	}
+	return false
+}
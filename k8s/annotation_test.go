@@ -0,0 +1,130 @@
+package k8s
+
+import (
+	"encoding/json"
+
+	kubesleep "github.com/Y0-L0/kubesleep/kubesleep"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	SCALED_OBJECT_KEY = "10:ScaledObject.keda.sh:test-scaledobject"
+	NOTEBOOK_KEY      = "10:Notebook.kubeflow.org:test-notebook"
+)
+
+func (s *Integrationtest) getAnnotations(gvr schema.GroupVersionResource, namespace, name string) map[string]string {
+	object, err := s.k8s.dynamic.Resource(gvr).Namespace(namespace).Get(s.ctx, name, metav1.GetOptions{})
+	s.Require().NoError(err)
+	return object.GetAnnotations()
+}
+
+func (s *Integrationtest) TestScaledObject_Get() {
+	deleteNamespace, err := testNamespace(s.ctx, "get-scaledobjects", s.k8s, false)
+	s.Require().NoError(err)
+	defer deleteNamespace()
+
+	delete, err := CreateCustomResource(s.ctx, *s.k8s, SCALED_OBJECT_GVR, "ScaledObject", "get-scaledobjects", "test-scaledobject", map[string]any{})
+	s.Require().NoError(err)
+	defer delete()
+
+	actual := s.getSuspendable("get-scaledobjects", SCALED_OBJECT_KEY)
+	actual.Suspend = nil
+	s.Require().Equal(
+		kubesleep.NewSuspendable(kubesleep.Annotated, "test-scaledobject", 0, nil).
+			WithResource("keda.sh/v1alpha1", "ScaledObject").
+			WithState([]byte(`{"annotation":"autoscaling.keda.sh/paused-replicas"}`)),
+		actual,
+	)
+}
+
+func (s *Integrationtest) TestScaledObject_SuspendAndWake() {
+	deleteNamespace, err := testNamespace(s.ctx, "suspend-scaledobjects", s.k8s, false)
+	s.Require().NoError(err)
+	defer deleteNamespace()
+
+	delete, err := CreateCustomResource(s.ctx, *s.k8s, SCALED_OBJECT_GVR, "ScaledObject", "suspend-scaledobjects", "test-scaledobject", map[string]any{})
+	s.Require().NoError(err)
+	defer delete()
+
+	before := s.getSuspendable("suspend-scaledobjects", SCALED_OBJECT_KEY)
+	s.Require().NoError(before.Suspend(s.ctx))
+	s.Require().Equal("0", s.getAnnotations(SCALED_OBJECT_GVR, "suspend-scaledobjects", "test-scaledobject")["autoscaling.keda.sh/paused-replicas"])
+
+	s.Require().NoError(s.k8s.ScaleSuspendable(s.ctx, "suspend-scaledobjects", before))
+	s.Require().NotContains(s.getAnnotations(SCALED_OBJECT_GVR, "suspend-scaledobjects", "test-scaledobject"), "autoscaling.keda.sh/paused-replicas")
+}
+
+func (s *Integrationtest) TestScaledJob_RestoresPreviousAnnotation() {
+	deleteNamespace, err := testNamespace(s.ctx, "suspend-scaledjobs", s.k8s, false)
+	s.Require().NoError(err)
+	defer deleteNamespace()
+
+	delete, err := CreateCustomResource(s.ctx, *s.k8s, SCALED_JOB_GVR, "ScaledJob", "suspend-scaledjobs", "test-scaledjob", map[string]any{})
+	s.Require().NoError(err)
+	defer delete()
+	patch, err := json.Marshal(map[string]any{"metadata": map[string]any{"annotations": map[string]string{"autoscaling.keda.sh/paused": "false"}}})
+	s.Require().NoError(err)
+	_, err = s.k8s.dynamic.Resource(SCALED_JOB_GVR).Namespace("suspend-scaledjobs").Patch(s.ctx, "test-scaledjob", types.MergePatchType, patch, metav1.PatchOptions{})
+	s.Require().NoError(err)
+
+	before := s.getSuspendable("suspend-scaledjobs", "10:ScaledJob.keda.sh:test-scaledjob")
+	s.Require().NoError(before.Suspend(s.ctx))
+	s.Require().Equal("true", s.getAnnotations(SCALED_JOB_GVR, "suspend-scaledjobs", "test-scaledjob")["autoscaling.keda.sh/paused"])
+
+	s.Require().NoError(s.k8s.ScaleSuspendable(s.ctx, "suspend-scaledjobs", before))
+	s.Require().Equal("false", s.getAnnotations(SCALED_JOB_GVR, "suspend-scaledjobs", "test-scaledjob")["autoscaling.keda.sh/paused"])
+}
+
+func (s *Integrationtest) TestNotebook_SuspendAndWake() {
+	deleteNamespace, err := testNamespace(s.ctx, "suspend-notebooks", s.k8s, false)
+	s.Require().NoError(err)
+	defer deleteNamespace()
+
+	delete, err := CreateCustomResource(s.ctx, *s.k8s, NOTEBOOK_GVR, "Notebook", "suspend-notebooks", "test-notebook", map[string]any{})
+	s.Require().NoError(err)
+	defer delete()
+	deleteStatefulSet, err := CreateStatefulSet(s.ctx, *s.k8s, "suspend-notebooks", "test-notebook", int32(1), metav1.OwnerReference{
+		APIVersion: "kubeflow.org/v1",
+		Kind:       "Notebook",
+		Name:       "test-notebook",
+		UID:        "d3b07384-d9a7-4c6b-8a8b-1f0d4e1c2b3a",
+		Controller: ptr.To(true),
+	})
+	s.Require().NoError(err)
+	defer deleteStatefulSet()
+
+	suspendables, err := s.k8s.GetSuspendables(s.ctx, "suspend-notebooks")
+	s.Require().NoError(err)
+	s.Require().NotContains(suspendables, "1:test-notebook")
+	s.Require().True(suspendables["20:StatefulSet.apps:test-notebook"].Owner().Suspended)
+	before := suspendables[NOTEBOOK_KEY]
+	s.Require().Equal(int32(1), before.Replicas)
+
+	s.Require().NoError(before.Suspend(s.ctx))
+	s.Require().NotEmpty(s.getAnnotations(NOTEBOOK_GVR, "suspend-notebooks", "test-notebook")["kubeflow-resource-stopped"])
+	suspended := s.getSuspendable("suspend-notebooks", NOTEBOOK_KEY)
+	s.Require().Equal(int32(0), suspended.Replicas)
+
+	s.Require().NoError(s.k8s.ScaleSuspendable(s.ctx, "suspend-notebooks", before))
+	s.Require().NotContains(s.getAnnotations(NOTEBOOK_GVR, "suspend-notebooks", "test-notebook"), "kubeflow-resource-stopped")
+}
+
+func (s *Unittest) TestAnnotationRuleIsAsleep() {
+	keda := annotationRule{annotation: "autoscaling.keda.sh/paused", asleep: "true"}
+	s.False(keda.isAsleep(nil))
+	s.False(keda.isAsleep(ptr.To("false")))
+	s.True(keda.isAsleep(ptr.To("true")))
+
+	kubeflow := annotationRule{annotation: "kubeflow-resource-stopped", timestamp: true}
+	s.False(kubeflow.isAsleep(nil))
+	s.True(kubeflow.isAsleep(ptr.To("2024-01-01T00:00:00Z")))
+	s.NotEmpty(kubeflow.asleepValue())
+}
+
+func (s *Unittest) TestHasAnnotationRule() {
+	s.Require().True(hasAnnotationRule(schema.GroupKind{Group: "keda.sh", Kind: "ScaledObject"}))
+	s.Require().False(hasAnnotationRule(schema.GroupKind{Group: "apps", Kind: "Deployment"}))
+}
@@ -0,0 +1,154 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	kubesleep "github.com/Y0-L0/kubesleep/kubesleep"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+)
+
+var ARGO_APPLICATION_GVK = schema.GroupVersionKind{Group: "argoproj.io", Version: "v1alpha1", Kind: "Application"}
+
+// Annotations that stop Argo CD from reporting the statefile as out of sync or pruning it.
+var argoCDStateFileAnnotations = map[string]string{
+	"argocd.argoproj.io/compare-options": "IgnoreExtraneous",
+	"argocd.argoproj.io/sync-options":    "Prune=false",
+}
+
+// argoApplicationState records the sync policy of an Application before its automated sync was disabled.
+type argoApplicationState struct {
+	SyncPolicy json.RawMessage `json:"syncPolicy,omitempty"`
+}
+
+// getArgoApplications returns the Argo CD Applications in any namespace that deploy into the given namespace.
+func (k8s K8Simpl) getArgoApplications(ctx context.Context, namespace string) (map[string]kubesleep.Suspendable, error) {
+	mapping, err := k8s.mapper.RESTMapping(ARGO_APPLICATION_GVK.GroupKind(), ARGO_APPLICATION_GVK.Version)
+	if meta.IsNoMatchError(err) {
+		slog.Debug("Argo CD is not installed; skipping Applications", "namespace", namespace)
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	applications, err := k8s.dynamic.Resource(mapping.Resource).List(ctx, metav1.ListOptions{})
+	if apierrors.IsForbidden(err) {
+		slog.Warn("Missing permissions to list Argo CD Applications; Argo CD may revert the suspension", "namespace", namespace)
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	suspendables := map[string]kubesleep.Suspendable{}
+
+	for _, application := range applications.Items {
+		destination, _, err := unstructured.NestedString(application.Object, "spec", "destination", "namespace")
+		if err != nil {
+			return nil, err
+		}
+		if destination != namespace {
+			continue
+		}
+
+		syncPolicy, found, err := unstructured.NestedMap(application.Object, "spec", "syncPolicy")
+		if err != nil {
+			return nil, err
+		}
+		var state argoApplicationState
+		if found {
+			if state.SyncPolicy, err = json.Marshal(syncPolicy); err != nil {
+				return nil, err
+			}
+		}
+		data, err := json.Marshal(state)
+		if err != nil {
+			return nil, err
+		}
+
+		key, err := cache.MetaNamespaceKeyFunc(&application)
+		if err != nil {
+			return nil, err
+		}
+
+		var suspend func(context.Context) error
+		if _, automated := syncPolicy["automated"]; automated {
+			suspend = k8s.suspendArgoApplication(mapping.Resource, application.GetNamespace(), application.GetName())
+		} else {
+			suspend = k8s.noopSuspendArgoApplication(application.GetNamespace(), application.GetName())
+		}
+
+		s := kubesleep.NewSuspendable(
+			kubesleep.ArgoApplication,
+			key,
+			0,
+			suspend,
+		).WithState(data)
+		slog.Debug("parsed Suspendable", "Suspendable", s, "namespace", namespace)
+		suspendables[s.Identifier()] = s
+	}
+
+	return suspendables, nil
+}
+
+func (k8s K8Simpl) noopSuspendArgoApplication(namespace, name string) func(context.Context) error {
+	return func(ctx context.Context) error {
+		slog.Debug("Argo CD Application has no automated sync; skipping suspend", "namespace", namespace, "name", name)
+		return nil
+	}
+}
+
+func (k8s K8Simpl) suspendArgoApplication(resource schema.GroupVersionResource, namespace, name string) func(context.Context) error {
+	return func(ctx context.Context) error {
+		patch := []byte(`{"spec":{"syncPolicy":{"automated":null}}}`)
+		_, err := k8s.dynamic.Resource(resource).Namespace(namespace).Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{})
+		if err != nil {
+			return err
+		}
+		slog.Info("Disabled automated sync of Argo CD Application", "name", name, "namespace", namespace)
+		return nil
+	}
+}
+
+func (k8s K8Simpl) wakeArgoApplication(ctx context.Context, suspendable kubesleep.Suspendable) error {
+	var state argoApplicationState
+	if err := json.Unmarshal(suspendable.State(), &state); err != nil {
+		return fmt.Errorf("invalid Argo CD Application state: %w", err)
+	}
+	if len(state.SyncPolicy) == 0 {
+		return nil
+	}
+
+	namespace, name, err := cache.SplitMetaNamespaceKey(suspendable.Name())
+	if err != nil {
+		return err
+	}
+	mapping, err := k8s.mapper.RESTMapping(ARGO_APPLICATION_GVK.GroupKind(), ARGO_APPLICATION_GVK.Version)
+	if err != nil {
+		return err
+	}
+
+	patch, err := json.Marshal(map[string]any{"spec": map[string]any{"syncPolicy": state.SyncPolicy}})
+	if err != nil {
+		return err
+	}
+	_, err = k8s.dynamic.Resource(mapping.Resource).Namespace(namespace).Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{})
+	if apierrors.IsNotFound(err) {
+		slog.Warn("Argo CD Application was deleted while the namespace was suspended; skipping wake", "name", name, "namespace", namespace)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	slog.Info("Restored sync policy of Argo CD Application", "name", name, "namespace", namespace)
+	return nil
+}
@@ -0,0 +1,71 @@
+package k8s
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func (s *Integrationtest) createArgoApplication(namespace, name, destination string, syncPolicy map[string]any) func() error {
+	spec := map[string]any{
+		"destination": map[string]any{"namespace": destination, "server": "https://kubernetes.default.svc"},
+	}
+	if syncPolicy != nil {
+		spec["syncPolicy"] = syncPolicy
+	}
+	delete, err := CreateCustomResource(s.ctx, *s.k8s, ARGO_APPLICATION_GVR, "Application", namespace, name, spec)
+	s.Require().NoError(err)
+	return delete
+}
+
+func (s *Integrationtest) getSyncPolicy(namespace, name string) (map[string]any, bool) {
+	application, err := s.k8s.dynamic.Resource(ARGO_APPLICATION_GVR).Namespace(namespace).Get(s.ctx, name, metav1.GetOptions{})
+	s.Require().NoError(err)
+	syncPolicy, found, err := unstructured.NestedMap(application.Object, "spec", "syncPolicy")
+	s.Require().NoError(err)
+	return syncPolicy, found
+}
+
+func (s *Integrationtest) TestArgoApplication_Get() {
+	deleteNamespace, err := testNamespace(s.ctx, "get-argo-applications", s.k8s, false)
+	s.Require().NoError(err)
+	defer deleteNamespace()
+	deleteArgoNamespace, err := testNamespace(s.ctx, "get-argo-applications-argocd", s.k8s, false)
+	s.Require().NoError(err)
+	defer deleteArgoNamespace()
+
+	defer s.createArgoApplication("get-argo-applications-argocd", "test-app", "get-argo-applications", nil)()
+	defer s.createArgoApplication("get-argo-applications-argocd", "other-app", "somewhere-else", nil)()
+
+	suspendables, err := s.k8s.GetSuspendables(s.ctx, "get-argo-applications")
+	s.Require().NoError(err)
+	s.Require().Contains(suspendables, "12:get-argo-applications-argocd/test-app")
+	s.Require().NotContains(suspendables, "12:get-argo-applications-argocd/other-app")
+}
+
+func (s *Integrationtest) TestArgoApplication_SuspendAndWake() {
+	deleteNamespace, err := testNamespace(s.ctx, "suspend-argo-applications", s.k8s, false)
+	s.Require().NoError(err)
+	defer deleteNamespace()
+	deleteArgoNamespace, err := testNamespace(s.ctx, "suspend-argo-applications-argocd", s.k8s, false)
+	s.Require().NoError(err)
+	defer deleteArgoNamespace()
+
+	syncPolicy := map[string]any{
+		"automated":   map[string]any{"prune": true, "selfHeal": true},
+		"syncOptions": []any{"CreateNamespace=true"},
+	}
+	defer s.createArgoApplication("suspend-argo-applications-argocd", "test-app", "suspend-argo-applications", syncPolicy)()
+
+	before := s.getSuspendable("suspend-argo-applications", "12:suspend-argo-applications-argocd/test-app")
+	s.Require().NoError(before.Suspend(s.ctx))
+
+	suspended, found := s.getSyncPolicy("suspend-argo-applications-argocd", "test-app")
+	s.Require().True(found)
+	s.Require().NotContains(suspended, "automated")
+	s.Require().Equal(syncPolicy["syncOptions"], suspended["syncOptions"])
+
+	s.Require().NoError(s.k8s.ScaleSuspendable(s.ctx, "suspend-argo-applications", before))
+
+	actual, _ := s.getSyncPolicy("suspend-argo-applications-argocd", "test-app")
+	s.Require().Equal(syncPolicy, actual)
+}
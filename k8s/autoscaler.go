@@ -0,0 +1,78 @@
+package k8s
+
+import (
+	"context"
+	"log/slog"
+
+	kubesleep "github.com/Y0-L0/kubesleep/kubesleep"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+)
+
+type scaleTarget struct {
+	groupKind schema.GroupKind
+	name      string
+}
+
+// attachAutoscalers records the HorizontalPodAutoscaler of every suspendable targeted by one.
+func (k8s K8Simpl) attachAutoscalers(ctx context.Context, namespace string, suspendables map[string]kubesleep.Suspendable) error {
+	hpas, err := k8s.clientset.AutoscalingV2().
+		HorizontalPodAutoscalers(namespace).
+		List(ctx, metav1.ListOptions{})
+	if apierrors.IsForbidden(err) {
+		slog.Warn("Missing permissions to list HorizontalPodAutoscalers; autoscaled workloads are woken with their recorded replicas", "namespace", namespace)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	targets := map[scaleTarget]string{}
+	for id, s := range suspendables {
+		if groupKind, ok := scaleTargetGroupKind(s); ok {
+			targets[scaleTarget{groupKind, s.Name()}] = id
+		}
+	}
+
+	for _, hpa := range hpas.Items {
+		ref := hpa.Spec.ScaleTargetRef
+		target := scaleTarget{schema.FromAPIVersionAndKind(ref.APIVersion, ref.Kind).GroupKind(), ref.Name}
+		id, found := targets[target]
+		if !found {
+			slog.Debug("HorizontalPodAutoscaler targets no suspendable", "namespace", namespace, "name", hpa.Name, "target", target)
+			continue
+		}
+
+		suspendables[id] = suspendables[id].WithAutoscaler(kubesleep.Autoscaler{
+			Name:        hpa.Name,
+			MinReplicas: ptr.Deref(hpa.Spec.MinReplicas, 1),
+			MaxReplicas: hpa.Spec.MaxReplicas,
+		})
+		slog.Debug("Suspendable is managed by a HorizontalPodAutoscaler", "namespace", namespace, "suspendable", id, "hpa", hpa.Name)
+	}
+	return nil
+}
+
+// scaleTargetGroupKind returns the kind a HorizontalPodAutoscaler uses to reference the suspendable.
+func scaleTargetGroupKind(s kubesleep.Suspendable) (schema.GroupKind, bool) {
+	switch s.ManifestType() {
+	case kubesleep.Deplyoment:
+		return schema.GroupKind{Group: "apps", Kind: "Deployment"}, true
+	case kubesleep.StatefulSet:
+		return schema.GroupKind{Group: "apps", Kind: "StatefulSet"}, true
+	case kubesleep.ReplicaSet:
+		return schema.GroupKind{Group: "apps", Kind: "ReplicaSet"}, true
+	case kubesleep.ReplicationController:
+		return schema.GroupKind{Kind: "ReplicationController"}, true
+	case kubesleep.Rollout:
+		return ROLLOUT_GVK.GroupKind(), true
+	case kubesleep.DeploymentConfig:
+		return DEPLOYMENT_CONFIG_GVK.GroupKind(), true
+	case kubesleep.Scalable:
+		return schema.FromAPIVersionAndKind(s.APIVersion(), s.Kind()).GroupKind(), true
+	default:
+		return schema.GroupKind{}, false
+	}
+}
@@ -0,0 +1,79 @@
+package k8s
+
+import (
+	"context"
+
+	kubesleep "github.com/Y0-L0/kubesleep/kubesleep"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+)
+
+func CreateHorizontalPodAutoscaler(ctx context.Context, k8s K8Simpl, namespace string, name string, target autoscalingv2.CrossVersionObjectReference, minReplicas, maxReplicas int32) (func() error, error) {
+	hpa := &autoscalingv2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: target,
+			MinReplicas:    ptr.To(minReplicas),
+			MaxReplicas:    maxReplicas,
+		},
+	}
+
+	_, err := k8s.clientset.AutoscalingV2().HorizontalPodAutoscalers(namespace).Create(ctx, hpa, metav1.CreateOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	delete := func() error {
+		return k8s.clientset.AutoscalingV2().HorizontalPodAutoscalers(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+	}
+	return delete, nil
+}
+
+func (s *Integrationtest) TestAutoscaledDeployment() {
+	deleteNamespace, err := testNamespace(s.ctx, "autoscaled-deployment", s.k8s, false)
+	s.Require().NoError(err)
+	defer deleteNamespace()
+
+	delete, err := CreateDeployment(s.ctx, *s.k8s, "autoscaled-deployment", "test-deployment", int32(5))
+	s.Require().NoError(err)
+	defer delete()
+	target := autoscalingv2.CrossVersionObjectReference{APIVersion: "apps/v1", Kind: "Deployment", Name: "test-deployment"}
+	deleteHPA, err := CreateHorizontalPodAutoscaler(s.ctx, *s.k8s, "autoscaled-deployment", "test-hpa", target, 2, 10)
+	s.Require().NoError(err)
+	defer deleteHPA()
+
+	before := s.getSuspendable("autoscaled-deployment", "0:test-deployment")
+	s.Require().Equal(int32(5), before.Replicas)
+	s.Require().Equal(&kubesleep.Autoscaler{Name: "test-hpa", MinReplicas: 2, MaxReplicas: 10}, before.Autoscaler())
+
+	s.Require().NoError(before.Suspend(s.ctx))
+	s.Require().NoError(s.k8s.ScaleSuspendable(s.ctx, "autoscaled-deployment", before))
+
+	actual := s.getSuspendable("autoscaled-deployment", "0:test-deployment")
+	s.Require().Equal(int32(2), actual.Replicas)
+}
+
+func (s *Unittest) TestScaleTargetGroupKind() {
+	tests := []struct {
+		suspendable kubesleep.Suspendable
+		expected    schema.GroupKind
+		ok          bool
+	}{
+		{kubesleep.NewSuspendable(kubesleep.Deplyoment, "a", 1, nil), schema.GroupKind{Group: "apps", Kind: "Deployment"}, true},
+		{kubesleep.NewSuspendable(kubesleep.ReplicationController, "a", 1, nil), schema.GroupKind{Kind: "ReplicationController"}, true},
+		{kubesleep.NewSuspendable(kubesleep.DeploymentConfig, "a", 1, nil), schema.GroupKind{Group: "apps.openshift.io", Kind: "DeploymentConfig"}, true},
+		{kubesleep.NewSuspendable(kubesleep.Scalable, "a", 1, nil).WithResource("apps.kruise.io/v1alpha1", "CloneSet"), schema.GroupKind{Group: "apps.kruise.io", Kind: "CloneSet"}, true},
+		{kubesleep.NewSuspendable(kubesleep.CronJob, "a", 0, nil), schema.GroupKind{}, false},
+	}
+
+	for _, test := range tests {
+		actual, ok := scaleTargetGroupKind(test.suspendable)
+		s.Equal(test.expected, actual)
+		s.Equal(test.ok, ok)
+	}
+}
@@ -0,0 +1,168 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	kubesleep "github.com/Y0-L0/kubesleep/kubesleep"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+var CNPG_CLUSTER_GVK = schema.GroupVersionKind{Group: "postgresql.cnpg.io", Version: "v1", Kind: "Cluster"}
+
+const (
+	CNPG_HIBERNATION_ANNOTATION = "cnpg.io/hibernation"
+	// CNPG_HIBERNATION_CONDITION is set to True by the operator once all instances are shut down.
+	CNPG_HIBERNATION_CONDITION = "cnpg.io/hibernation"
+)
+
+// operatorPollInterval and operatorTimeout bound the wait for an operator to act on a suspended resource.
+var (
+	operatorPollInterval = 2 * time.Second
+	operatorTimeout      = 10 * time.Minute
+)
+
+// getCNPGClusters returns the CloudNativePG clusters of the namespace. They are suspended with declarative hibernation,
+// the original value of the hibernation annotation is kept in the state.
+func (k8s K8Simpl) getCNPGClusters(ctx context.Context, namespace string) (map[string]kubesleep.Suspendable, error) {
+	mapping, err := k8s.mapper.RESTMapping(CNPG_CLUSTER_GVK.GroupKind(), CNPG_CLUSTER_GVK.Version)
+	if meta.IsNoMatchError(err) {
+		slog.Debug("CloudNativePG is not installed; skipping Clusters", "namespace", namespace)
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	clusters, err := k8s.dynamic.Resource(mapping.Resource).
+		Namespace(namespace).
+		List(ctx, metav1.ListOptions{})
+	if apierrors.IsForbidden(err) {
+		slog.Warn("Missing permissions to list CloudNativePG Clusters; skipping them", "namespace", namespace)
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	suspendables := map[string]kubesleep.Suspendable{}
+
+	for _, cluster := range clusters.Items {
+		state := annotationState{Annotation: CNPG_HIBERNATION_ANNOTATION}
+		if value, found := cluster.GetAnnotations()[CNPG_HIBERNATION_ANNOTATION]; found {
+			state.Value = &value
+		}
+		data, err := json.Marshal(state)
+		if err != nil {
+			return nil, err
+		}
+
+		var suspend func(context.Context) error
+		var instances int64
+		if state.Value != nil && *state.Value == "on" {
+			suspend = k8s.noopSuspendCNPGCluster(namespace, cluster.GetName())
+		} else {
+			suspend = k8s.suspendCNPGCluster(namespace, mapping.Resource, cluster.GetName())
+			instances, _, err = unstructured.NestedInt64(cluster.Object, "spec", "instances")
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		s := kubesleep.NewSuspendable(
+			kubesleep.PostgresCluster,
+			cluster.GetName(),
+			int32(instances),
+			suspend,
+		).WithState(data)
+		slog.Debug("parsed Suspendable", "Suspendable", s, "namespace", namespace)
+		suspendables[s.Identifier()] = s
+	}
+
+	return suspendables, nil
+}
+
+func (k8s K8Simpl) noopSuspendCNPGCluster(namespace, name string) func(context.Context) error {
+	return func(ctx context.Context) error {
+		slog.Debug("CloudNativePG Cluster already hibernated; skipping suspend", "namespace", namespace, "name", name)
+		return nil
+	}
+}
+
+func (k8s K8Simpl) suspendCNPGCluster(namespace string, resource schema.GroupVersionResource, name string) func(context.Context) error {
+	return func(ctx context.Context) error {
+		on := "on"
+		if err := k8s.patchAnnotation(ctx, namespace, resource, name, CNPG_HIBERNATION_ANNOTATION, &on); err != nil {
+			return err
+		}
+		slog.Info("Waiting for CloudNativePG Cluster to hibernate", "name", name, "namespace", namespace)
+		if err := k8s.waitForCNPGCondition(ctx, namespace, resource, name, CNPG_HIBERNATION_CONDITION); err != nil {
+			return fmt.Errorf("CloudNativePG Cluster %s did not hibernate: %w", name, err)
+		}
+		slog.Info("Suspended CloudNativePG Cluster", "name", name, "namespace", namespace)
+		return nil
+	}
+}
+
+// wakeCNPGCluster restores the hibernation annotation and waits until the cluster is ready,
+// so the applications woken afterwards find their database running.
+func (k8s K8Simpl) wakeCNPGCluster(ctx context.Context, namespace string, suspendable kubesleep.Suspendable) error {
+	var state annotationState
+	if err := json.Unmarshal(suspendable.State(), &state); err != nil {
+		return fmt.Errorf("invalid CloudNativePG Cluster state: %w", err)
+	}
+
+	mapping, err := k8s.mapper.RESTMapping(CNPG_CLUSTER_GVK.GroupKind(), CNPG_CLUSTER_GVK.Version)
+	if err != nil {
+		return err
+	}
+
+	if err := k8s.patchAnnotation(ctx, namespace, mapping.Resource, suspendable.Name(), state.Annotation, state.Value); err != nil {
+		return err
+	}
+	if state.Value != nil && *state.Value == "on" {
+		slog.Info("CloudNativePG Cluster was hibernated before kubesleep; leaving it hibernated", "name", suspendable.Name(), "namespace", namespace)
+		return nil
+	}
+
+	slog.Info("Waiting for CloudNativePG Cluster to become ready", "name", suspendable.Name(), "namespace", namespace)
+	if err := k8s.waitForCNPGCondition(ctx, namespace, mapping.Resource, suspendable.Name(), "Ready"); err != nil {
+		return fmt.Errorf("CloudNativePG Cluster %s did not become ready: %w", suspendable.Name(), err)
+	}
+	slog.Info("Woke up CloudNativePG Cluster", "name", suspendable.Name(), "namespace", namespace)
+	return nil
+}
+
+// waitForCNPGCondition polls the cluster until the condition of the given type is True.
+func (k8s K8Simpl) waitForCNPGCondition(ctx context.Context, namespace string, resource schema.GroupVersionResource, name, conditionType string) error {
+	return wait.PollUntilContextTimeout(ctx, operatorPollInterval, operatorTimeout, true, func(ctx context.Context) (bool, error) {
+		cluster, err := k8s.dynamic.Resource(resource).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		return hasTrueCondition(cluster.Object, conditionType), nil
+	})
+}
+
+// hasTrueCondition reports whether status.conditions of an unstructured object contains the type with status True.
+func hasTrueCondition(object map[string]any, conditionType string) bool {
+	conditions, _, _ := unstructured.NestedSlice(object, "status", "conditions")
+	for _, condition := range conditions {
+		fields, ok := condition.(map[string]any)
+		if !ok {
+			continue
+		}
+		if fields["type"] == conditionType {
+			return fields["status"] == string(metav1.ConditionTrue)
+		}
+	}
+	return false
+}
@@ -0,0 +1,110 @@
+package k8s
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const CNPG_CLUSTER_KEY = "14:test-cluster"
+
+// createCNPGCluster creates a Cluster whose status reports the given conditions as True,
+// standing in for the operator that is not running in the test control plane.
+func (s *Integrationtest) createCNPGCluster(namespace string, annotations map[string]string, conditions ...string) func() error {
+	delete, err := CreateCustomResource(s.ctx, *s.k8s, CNPG_CLUSTER_GVR, "Cluster", namespace, "test-cluster", map[string]any{"instances": int64(3)})
+	s.Require().NoError(err)
+
+	cluster, err := s.k8s.dynamic.Resource(CNPG_CLUSTER_GVR).Namespace(namespace).Get(s.ctx, "test-cluster", metav1.GetOptions{})
+	s.Require().NoError(err)
+	var statusConditions []any
+	for _, condition := range conditions {
+		statusConditions = append(statusConditions, map[string]any{"type": condition, "status": "True"})
+	}
+	s.Require().NoError(unstructured.SetNestedSlice(cluster.Object, statusConditions, "status", "conditions"))
+	cluster.SetAnnotations(annotations)
+	_, err = s.k8s.dynamic.Resource(CNPG_CLUSTER_GVR).Namespace(namespace).Update(s.ctx, cluster, metav1.UpdateOptions{})
+	s.Require().NoError(err)
+	return delete
+}
+
+func (s *Integrationtest) getCNPGAnnotation(namespace string) (string, bool) {
+	cluster, err := s.k8s.dynamic.Resource(CNPG_CLUSTER_GVR).Namespace(namespace).Get(s.ctx, "test-cluster", metav1.GetOptions{})
+	s.Require().NoError(err)
+	value, found := cluster.GetAnnotations()[CNPG_HIBERNATION_ANNOTATION]
+	return value, found
+}
+
+func (s *Integrationtest) TestCNPGCluster_Get() {
+	deleteNamespace, err := testNamespace(s.ctx, "get-cnpg", s.k8s, false)
+	s.Require().NoError(err)
+	defer deleteNamespace()
+	defer s.createCNPGCluster("get-cnpg", nil)()
+
+	actual := s.getSuspendable("get-cnpg", CNPG_CLUSTER_KEY)
+	s.Require().Equal(int32(3), actual.Replicas)
+}
+
+func (s *Integrationtest) TestCNPGCluster_SuspendAndWake() {
+	deleteNamespace, err := testNamespace(s.ctx, "suspend-cnpg", s.k8s, false)
+	s.Require().NoError(err)
+	defer deleteNamespace()
+	defer s.createCNPGCluster("suspend-cnpg", nil, CNPG_HIBERNATION_CONDITION, "Ready")()
+
+	before := s.getSuspendable("suspend-cnpg", CNPG_CLUSTER_KEY)
+	s.Require().NoError(before.Suspend(s.ctx))
+
+	value, found := s.getCNPGAnnotation("suspend-cnpg")
+	s.Require().True(found)
+	s.Require().Equal("on", value)
+	suspended := s.getSuspendable("suspend-cnpg", CNPG_CLUSTER_KEY)
+	s.Require().Equal(int32(0), suspended.Replicas)
+
+	s.Require().NoError(s.k8s.ScaleSuspendable(s.ctx, "suspend-cnpg", before))
+
+	_, found = s.getCNPGAnnotation("suspend-cnpg")
+	s.Require().False(found)
+}
+
+func (s *Integrationtest) TestCNPGCluster_KeepsManualHibernation() {
+	deleteNamespace, err := testNamespace(s.ctx, "manual-cnpg", s.k8s, false)
+	s.Require().NoError(err)
+	defer deleteNamespace()
+	defer s.createCNPGCluster("manual-cnpg", map[string]string{CNPG_HIBERNATION_ANNOTATION: "on"}, CNPG_HIBERNATION_CONDITION)()
+
+	before := s.getSuspendable("manual-cnpg", CNPG_CLUSTER_KEY)
+	s.Require().Equal(int32(0), before.Replicas)
+	s.Require().NoError(before.Suspend(s.ctx))
+
+	s.Require().NoError(s.k8s.ScaleSuspendable(s.ctx, "manual-cnpg", before))
+
+	value, _ := s.getCNPGAnnotation("manual-cnpg")
+	s.Require().Equal("on", value)
+}
+
+func (s *Integrationtest) TestCNPGCluster_SuspendTimesOut() {
+	deleteNamespace, err := testNamespace(s.ctx, "timeout-cnpg", s.k8s, false)
+	s.Require().NoError(err)
+	defer deleteNamespace()
+	defer s.createCNPGCluster("timeout-cnpg", nil)()
+
+	timeout := operatorTimeout
+	operatorTimeout = 100 * time.Millisecond
+	defer func() { operatorTimeout = timeout }()
+
+	before := s.getSuspendable("timeout-cnpg", CNPG_CLUSTER_KEY)
+	err = before.Suspend(s.ctx)
+	s.Require().ErrorContains(err, "did not hibernate")
+}
+
+func (s *Unittest) TestHasTrueCondition() {
+	object := map[string]any{"status": map[string]any{"conditions": []any{
+		map[string]any{"type": "Ready", "status": "False"},
+		map[string]any{"type": CNPG_HIBERNATION_CONDITION, "status": "True"},
+	}}}
+
+	s.True(hasTrueCondition(object, CNPG_HIBERNATION_CONDITION))
+	s.False(hasTrueCondition(object, "Ready"))
+	s.False(hasTrueCondition(object, "Missing"))
+	s.False(hasTrueCondition(map[string]any{}, "Ready"))
+}
@@ -0,0 +1,126 @@
+package k8s
+
+import (
+	"context"
+	"strings"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var (
+	WIDGET_GVR = schema.GroupVersionResource{Group: "test.kubesleep.xyz", Version: "v1", Resource: "widgets"}
+	GADGET_GVR = schema.GroupVersionResource{Group: "test.kubesleep.xyz", Version: "v1", Resource: "gadgets"}
+
+	SCALED_OBJECT_GVR = schema.GroupVersionResource{Group: "keda.sh", Version: "v1alpha1", Resource: "scaledobjects"}
+	SCALED_JOB_GVR    = schema.GroupVersionResource{Group: "keda.sh", Version: "v1alpha1", Resource: "scaledjobs"}
+
+	ROLLOUT_GVR          = schema.GroupVersionResource{Group: "argoproj.io", Version: "v1alpha1", Resource: "rollouts"}
+	ARGO_APPLICATION_GVR = schema.GroupVersionResource{Group: "argoproj.io", Version: "v1alpha1", Resource: "applications"}
+
+	FLUX_KUSTOMIZATION_GVR = schema.GroupVersionResource{Group: "kustomize.toolkit.fluxcd.io", Version: "v1", Resource: "kustomizations"}
+	FLUX_HELM_RELEASE_GVR  = schema.GroupVersionResource{Group: "helm.toolkit.fluxcd.io", Version: "v2", Resource: "helmreleases"}
+
+	CNPG_CLUSTER_GVR = schema.GroupVersionResource{Group: "postgresql.cnpg.io", Version: "v1", Resource: "clusters"}
+
+	VIRTUAL_MACHINE_GVR = schema.GroupVersionResource{Group: "kubevirt.io", Version: "v1", Resource: "virtualmachines"}
+
+	NOTEBOOK_GVR = schema.GroupVersionResource{Group: "kubeflow.org", Version: "v1", Resource: "notebooks"}
+
+	KAFKA_GVR           = schema.GroupVersionResource{Group: "kafka.strimzi.io", Version: "v1beta2", Resource: "kafkas"}
+	KAFKA_NODE_POOL_GVR = schema.GroupVersionResource{Group: "kafka.strimzi.io", Version: "v1beta2", Resource: "kafkanodepools"}
+
+	DEPLOYMENT_CONFIG_GVR = schema.GroupVersionResource{Group: "apps.openshift.io", Version: "v1", Resource: "deploymentconfigs"}
+
+	CRON_WORKFLOW_GVR = schema.GroupVersionResource{Group: "argoproj.io", Version: "v1alpha1", Resource: "cronworkflows"}
+	WORKFLOW_GVR      = schema.GroupVersionResource{Group: "argoproj.io", Version: "v1alpha1", Resource: "workflows"}
+)
+
+// testCRDs are installed into the testing control plane on startup.
+var testCRDs = []*apiextensionsv1.CustomResourceDefinition{
+	testCRD(WIDGET_GVR, "Widget", true),
+	testCRD(GADGET_GVR, "Gadget", false),
+	testCRD(SCALED_OBJECT_GVR, "ScaledObject", false),
+	testCRD(SCALED_JOB_GVR, "ScaledJob", false),
+	testCRD(ROLLOUT_GVR, "Rollout", true),
+	testCRD(ARGO_APPLICATION_GVR, "Application", false),
+	testCRD(FLUX_KUSTOMIZATION_GVR, "Kustomization", false),
+	testCRD(FLUX_HELM_RELEASE_GVR, "HelmRelease", false),
+	testCRD(CNPG_CLUSTER_GVR, "Cluster", false),
+	testCRD(VIRTUAL_MACHINE_GVR, "VirtualMachine", false),
+	testCRD(NOTEBOOK_GVR, "Notebook", false),
+	testCRD(KAFKA_GVR, "Kafka", false),
+	testCRD(KAFKA_NODE_POOL_GVR, "KafkaNodePool", true),
+	testCRD(DEPLOYMENT_CONFIG_GVR, "DeploymentConfig", true),
+	testCRD(CRON_WORKFLOW_GVR, "CronWorkflow", false),
+	testCRD(WORKFLOW_GVR, "Workflow", false),
+}
+
+// testCRD builds a schemaless, namespaced custom resource definition.
+// With scale enabled, the /scale subresource maps to .spec.replicas.
+func testCRD(gvr schema.GroupVersionResource, kind string, scale bool) *apiextensionsv1.CustomResourceDefinition {
+	preserveUnknownFields := true
+	var subresources *apiextensionsv1.CustomResourceSubresources
+	if scale {
+		subresources = &apiextensionsv1.CustomResourceSubresources{
+			Scale: &apiextensionsv1.CustomResourceSubresourceScale{
+				SpecReplicasPath:   ".spec.replicas",
+				StatusReplicasPath: ".status.replicas",
+			},
+		}
+	}
+
+	return &apiextensionsv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: gvr.Resource + "." + gvr.Group,
+		},
+		Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+			Group: gvr.Group,
+			Scope: apiextensionsv1.NamespaceScoped,
+			Names: apiextensionsv1.CustomResourceDefinitionNames{
+				Plural:   gvr.Resource,
+				Singular: strings.ToLower(kind),
+				Kind:     kind,
+				ListKind: kind + "List",
+			},
+			Versions: []apiextensionsv1.CustomResourceDefinitionVersion{
+				{
+					Name:    gvr.Version,
+					Served:  true,
+					Storage: true,
+					Schema: &apiextensionsv1.CustomResourceValidation{
+						OpenAPIV3Schema: &apiextensionsv1.JSONSchemaProps{
+							Type:                   "object",
+							XPreserveUnknownFields: &preserveUnknownFields,
+						},
+					},
+					Subresources: subresources,
+				},
+			},
+		},
+	}
+}
+
+func CreateCustomResource(ctx context.Context, k8s K8Simpl, gvr schema.GroupVersionResource, kind string, namespace string, name string, spec map[string]any) (func() error, error) {
+	object := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": gvr.GroupVersion().String(),
+		"kind":       kind,
+		"metadata": map[string]any{
+			"name":      name,
+			"namespace": namespace,
+		},
+		"spec": spec,
+	}}
+
+	_, err := k8s.dynamic.Resource(gvr).Namespace(namespace).Create(ctx, object, metav1.CreateOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	delete := func() error {
+		return k8s.dynamic.Resource(gvr).Namespace(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+	}
+	return delete, nil
+}
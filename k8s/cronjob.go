@@ -2,12 +2,23 @@ package k8s
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"log/slog"
+	"time"
 
 	kubesleep "github.com/Y0-L0/kubesleep/kubesleep"
+	batchv1 "k8s.io/api/batch/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 )
 
+// cronJobState records the running Jobs of a CronJob that were suspended together with it.
+type cronJobState struct {
+	SuspendedJobs []string `json:"suspendedJobs,omitempty"`
+}
+
 func (k8s K8Simpl) getCronJobs(ctx context.Context, namespace string) (map[string]kubesleep.Suspendable, error) {
 	cronJobs, err := k8s.clientset.BatchV1().
 		CronJobs(namespace).
@@ -19,19 +30,33 @@ func (k8s K8Simpl) getCronJobs(ctx context.Context, namespace string) (map[strin
 	suspendables := map[string]kubesleep.Suspendable{}
 
 	for _, job := range cronJobs.Items {
+		activeJobs, err := k8s.getActiveJobs(ctx, job)
+		if err != nil {
+			return nil, err
+		}
+
+		// A missing suspend flag defaults to false.
+		suspended := ptr.Deref(job.Spec.Suspend, false)
 		var suspend func(context.Context) error
-		if job.Spec.Suspend != nil && *job.Spec.Suspend {
+		if suspended && len(activeJobs) == 0 {
 			suspend = k8s.noopSuspendCronJob(namespace, job.Name)
 		} else {
-			suspend = k8s.suspendCronJob(namespace, job.Name)
+			suspend = k8s.suspendCronJob(namespace, job.Name, activeJobs)
 		}
 
 		s := kubesleep.NewSuspendable(
 			kubesleep.CronJob,
 			job.Name,
-			suspendedToReplicas(*job.Spec.Suspend),
+			0,
 			suspend,
-		)
+		).WithSuspended(suspended)
+		if k8s.options.ActiveJobs == kubesleep.SuspendActiveJobs && len(activeJobs) > 0 {
+			state, err := json.Marshal(cronJobState{SuspendedJobs: activeJobs})
+			if err != nil {
+				return nil, err
+			}
+			s = s.WithState(state)
+		}
 		slog.Debug("parsed Suspendable", "Suspendable", s, "namespace", namespace)
 		suspendables[s.Identifier()] = s
 	}
@@ -39,6 +64,30 @@ func (k8s K8Simpl) getCronJobs(ctx context.Context, namespace string) (map[strin
 	return suspendables, nil
 }
 
+// getActiveJobs returns the running, not yet suspended Jobs of a CronJob
+// if the configured ActiveJobPolicy requires handling them.
+func (k8s K8Simpl) getActiveJobs(ctx context.Context, cronJob batchv1.CronJob) ([]string, error) {
+	if k8s.options.ActiveJobs == kubesleep.KeepActiveJobs {
+		return nil, nil
+	}
+
+	var result []string
+	for _, ref := range cronJob.Status.Active {
+		job, err := k8s.clientset.BatchV1().Jobs(cronJob.Namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if jobFinished(*job) || (job.Spec.Suspend != nil && *job.Spec.Suspend) {
+			continue
+		}
+		result = append(result, job.Name)
+	}
+	return result, nil
+}
+
 func (k8s K8Simpl) noopSuspendCronJob(namespace, name string) func(context.Context) error {
 	return func(ctx context.Context) error {
 		slog.Debug("CronJob already suspended; skipping suspend", "namespace", namespace, "name", name)
@@ -46,15 +95,81 @@ func (k8s K8Simpl) noopSuspendCronJob(namespace, name string) func(context.Conte
 	}
 }
 
-func (k8s K8Simpl) suspendCronJob(namespace, name string) func(context.Context) error {
+func (k8s K8Simpl) suspendCronJob(namespace, name string, activeJobs []string) func(context.Context) error {
 	return func(ctx context.Context) error {
 		result := true
-		return k8s.setCronJobSuspended(ctx, namespace, name, &result)
+		if err := k8s.setCronJobSuspended(ctx, namespace, name, &result); err != nil {
+			return err
+		}
+		for _, job := range activeJobs {
+			if err := k8s.stopActiveJob(ctx, namespace, job); err != nil {
+				return err
+			}
+		}
+		return nil
 	}
 }
 
-func (k8s K8Simpl) scaleCronJob(ctx context.Context, namespace, name string, replicas int32) error {
-	return k8s.setCronJobSuspended(ctx, namespace, name, replicasToSuspended(replicas))
+func (k8s K8Simpl) stopActiveJob(ctx context.Context, namespace, name string) error {
+	var err error
+	switch k8s.options.ActiveJobs {
+	case kubesleep.SuspendActiveJobs:
+		err = k8s.setJobSuspended(ctx, namespace, name, ptr.To(true))
+	case kubesleep.TerminateActiveJobs:
+		err = k8s.clientset.BatchV1().Jobs(namespace).Delete(ctx, name, metav1.DeleteOptions{
+			PropagationPolicy: ptr.To(metav1.DeletePropagationBackground),
+		})
+		if err == nil {
+			slog.Info("Terminated active Job of CronJob", "name", name, "namespace", namespace)
+		}
+	default:
+		panic(fmt.Sprintf("unexpected active jobs policy %q", k8s.options.ActiveJobs))
+	}
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+func (k8s K8Simpl) scaleCronJob(ctx context.Context, namespace string, suspendable kubesleep.Suspendable) error {
+	var state cronJobState
+	if suspendable.State() != nil {
+		if err := json.Unmarshal(suspendable.State(), &state); err != nil {
+			return fmt.Errorf("invalid CronJob state: %w", err)
+		}
+	}
+
+	for _, job := range state.SuspendedJobs {
+		if err := k8s.scaleJob(ctx, namespace, job, false); err != nil {
+			return err
+		}
+	}
+
+	suspended := suspendable.Suspended()
+	if !suspended && k8s.options.SkipMissedRuns {
+		if err := k8s.skipMissedCronJobRuns(ctx, namespace, suspendable.Name()); err != nil {
+			return err
+		}
+	}
+	return k8s.setCronJobSuspended(ctx, namespace, suspendable.Name(), &suspended)
+}
+
+// skipMissedCronJobRuns moves the last schedule time of a CronJob to now.
+// The CronJob controller computes missed runs from this timestamp, so none are started on resume.
+func (k8s K8Simpl) skipMissedCronJobRuns(ctx context.Context, namespace, name string) error {
+	cj, err := k8s.clientset.BatchV1().CronJobs(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	cj.Status.LastScheduleTime = &metav1.Time{Time: time.Now()}
+
+	_, err = k8s.clientset.BatchV1().CronJobs(namespace).UpdateStatus(ctx, cj, metav1.UpdateOptions{})
+	if err != nil {
+		return err
+	}
+	slog.Debug("Skipped missed runs of CronJob", "name", name, "namespace", namespace)
+	return nil
 }
 
 func (k8s K8Simpl) setCronJobSuspended(ctx context.Context, namespace, name string, suspended *bool) error {
@@ -84,23 +199,3 @@ func (k8s K8Simpl) setCronJobSuspended(ctx context.Context, namespace, name stri
 	}
 	return nil
 }
-
-func suspendedToReplicas(b bool) int32 {
-	if b {
-		return int32(0)
-	}
-	return int32(1)
-}
-
-func replicasToSuspended(i int32) *bool {
-	var result bool
-	switch i {
-	case 0:
-		result = true
-	case 1:
-		result = false
-	default:
-		panic("can't scale CronJob beyond 1=on")
-	}
-	return &result
-}
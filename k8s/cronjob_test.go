@@ -2,13 +2,20 @@ package k8s
 
 import (
 	"context"
+	"time"
+
 	kubesleep "github.com/Y0-L0/kubesleep/kubesleep"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func CreateCronJob(ctx context.Context, k8s K8Simpl, namespace string, name string, suspended bool) (func() error, error) {
+	return createCronJob(ctx, k8s, namespace, name, &suspended)
+}
+
+func createCronJob(ctx context.Context, k8s K8Simpl, namespace string, name string, suspended *bool) (func() error, error) {
 	cj := &batchv1.CronJob{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      name,
@@ -16,7 +23,7 @@ func CreateCronJob(ctx context.Context, k8s K8Simpl, namespace string, name stri
 		},
 		Spec: batchv1.CronJobSpec{
 			Schedule: "*/1 * * * *",
-			Suspend:  func(b bool) *bool { v := b; return &v }(suspended),
+			Suspend:  suspended,
 			JobTemplate: batchv1.JobTemplateSpec{
 				Spec: batchv1.JobSpec{
 					Template: corev1.PodTemplateSpec{
@@ -72,9 +79,9 @@ func (s *Integrationtest) TestCronJobs_Get() {
 		kubesleep.NewSuspendable(
 			kubesleep.CronJob,
 			"test-cronjob",
-			int32(1),
+			int32(0),
 			nil,
-		),
+		).WithSuspended(false),
 		actual,
 	)
 }
@@ -89,12 +96,12 @@ func (s *Integrationtest) TestCronJob_Suspend() {
 	defer delete()
 
 	before := s.getSuspendable("suspend-cronjobs", "2:test-cronjob")
-	s.Require().Equal(int32(1), before.Replicas)
+	s.Require().False(before.Suspended())
 
 	s.Require().NoError(before.Suspend(s.ctx))
 
 	actual := s.getSuspendable("suspend-cronjobs", "2:test-cronjob")
-	s.Require().Equal(int32(0), actual.Replicas)
+	s.Require().True(actual.Suspended())
 }
 
 func (s *Integrationtest) TestCronJob_Scale() {
@@ -106,11 +113,11 @@ func (s *Integrationtest) TestCronJob_Scale() {
 	s.Require().NoError(err)
 	defer delete()
 
-	err = s.k8s.ScaleSuspendable(s.ctx, "scale-cronjobs", kubesleep.CronJob, "test-cronjob", 1)
+	err = s.k8s.ScaleSuspendable(s.ctx, "scale-cronjobs", kubesleep.NewSuspendable(kubesleep.CronJob, "test-cronjob", 0, nil).WithSuspended(false))
 	s.Require().NoError(err)
 
 	actual := s.getSuspendable("scale-cronjobs", "2:test-cronjob")
-	s.Require().Equal(int32(1), actual.Replicas)
+	s.Require().False(actual.Suspended())
 }
 
 func (s *Integrationtest) TestCronJob_SuspendNoopWhenAlreadySuspended() {
@@ -141,3 +148,120 @@ func (s *Integrationtest) TestCronJob_SuspendNoopWhenAlreadySuspended() {
 	s.Require().NoError(err)
 	s.Equal(before.ResourceVersion, after.ResourceVersion, "cronjob resourceVersion changed; suspend should be a no-op when already suspended")
 }
+
+// CreateActiveCronJobJob creates a running Job and registers it as active in the status of the CronJob.
+func CreateActiveCronJobJob(ctx context.Context, k8s K8Simpl, namespace, cronJob, name string) (func() error, error) {
+	cj, err := k8s.clientset.BatchV1().CronJobs(namespace).Get(ctx, cronJob, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	delete, err := CreateJob(ctx, k8s, namespace, name, false, *metav1.NewControllerRef(cj, batchv1.SchemeGroupVersion.WithKind("CronJob")))
+	if err != nil {
+		return nil, err
+	}
+
+	cj.Status.Active = append(cj.Status.Active, corev1.ObjectReference{Kind: "Job", Namespace: namespace, Name: name})
+	_, err = k8s.clientset.BatchV1().CronJobs(namespace).UpdateStatus(ctx, cj, metav1.UpdateOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return delete, nil
+}
+
+func (s *Integrationtest) TestCronJob_SuspendActiveJobs() {
+	deleteNamespace, err := testNamespace(s.ctx, "suspend-cronjob-active-jobs", s.k8s, false)
+	s.Require().NoError(err)
+	defer deleteNamespace()
+
+	delete, err := CreateCronJob(s.ctx, *s.k8s, "suspend-cronjob-active-jobs", "test-cronjob", false)
+	s.Require().NoError(err)
+	defer delete()
+	deleteJob, err := CreateActiveCronJobJob(s.ctx, *s.k8s, "suspend-cronjob-active-jobs", "test-cronjob", "test-job")
+	s.Require().NoError(err)
+	defer deleteJob()
+
+	k8s := *s.k8s
+	k8s.options.ActiveJobs = kubesleep.SuspendActiveJobs
+
+	suspendables, err := k8s.GetSuspendables(s.ctx, "suspend-cronjob-active-jobs")
+	s.Require().NoError(err)
+	before := suspendables["2:test-cronjob"]
+	s.Require().JSONEq(`{"suspendedJobs": ["test-job"]}`, string(before.State()))
+	s.Require().NoError(before.Suspend(s.ctx))
+
+	job, err := s.k8s.clientset.BatchV1().Jobs("suspend-cronjob-active-jobs").Get(s.ctx, "test-job", metav1.GetOptions{})
+	s.Require().NoError(err)
+	s.Require().True(*job.Spec.Suspend)
+
+	s.Require().NoError(k8s.ScaleSuspendable(s.ctx, "suspend-cronjob-active-jobs", before))
+
+	job, err = s.k8s.clientset.BatchV1().Jobs("suspend-cronjob-active-jobs").Get(s.ctx, "test-job", metav1.GetOptions{})
+	s.Require().NoError(err)
+	s.Require().False(*job.Spec.Suspend)
+	s.Require().False(s.getSuspendable("suspend-cronjob-active-jobs", "2:test-cronjob").Suspended())
+}
+
+func (s *Integrationtest) TestCronJob_TerminateActiveJobs() {
+	deleteNamespace, err := testNamespace(s.ctx, "terminate-cronjob-active-jobs", s.k8s, false)
+	s.Require().NoError(err)
+	defer deleteNamespace()
+
+	delete, err := CreateCronJob(s.ctx, *s.k8s, "terminate-cronjob-active-jobs", "test-cronjob", false)
+	s.Require().NoError(err)
+	defer delete()
+	_, err = CreateActiveCronJobJob(s.ctx, *s.k8s, "terminate-cronjob-active-jobs", "test-cronjob", "test-job")
+	s.Require().NoError(err)
+
+	k8s := *s.k8s
+	k8s.options.ActiveJobs = kubesleep.TerminateActiveJobs
+
+	suspendables, err := k8s.GetSuspendables(s.ctx, "terminate-cronjob-active-jobs")
+	s.Require().NoError(err)
+	before := suspendables["2:test-cronjob"]
+	s.Require().Nil(before.State())
+	s.Require().NoError(before.Suspend(s.ctx))
+
+	_, err = s.k8s.clientset.BatchV1().Jobs("terminate-cronjob-active-jobs").Get(s.ctx, "test-job", metav1.GetOptions{})
+	s.Require().True(apierrors.IsNotFound(err), "expected the active Job to be deleted, got %v", err)
+	s.Require().True(s.getSuspendable("terminate-cronjob-active-jobs", "2:test-cronjob").Suspended())
+}
+
+func (s *Integrationtest) TestCronJob_ScaleSkipMissedRuns() {
+	deleteNamespace, err := testNamespace(s.ctx, "scale-cronjob-skip-missed", s.k8s, false)
+	s.Require().NoError(err)
+	defer deleteNamespace()
+
+	delete, err := CreateCronJob(s.ctx, *s.k8s, "scale-cronjob-skip-missed", "test-cronjob", true)
+	s.Require().NoError(err)
+	defer delete()
+
+	k8s := *s.k8s
+	k8s.options.SkipMissedRuns = true
+	start := time.Now().Add(-time.Second)
+
+	err = k8s.ScaleSuspendable(s.ctx, "scale-cronjob-skip-missed", kubesleep.NewSuspendable(kubesleep.CronJob, "test-cronjob", 0, nil).WithSuspended(false))
+	s.Require().NoError(err)
+
+	cj, err := s.k8s.clientset.BatchV1().CronJobs("scale-cronjob-skip-missed").Get(s.ctx, "test-cronjob", metav1.GetOptions{})
+	s.Require().NoError(err)
+	s.Require().False(*cj.Spec.Suspend)
+	s.Require().NotNil(cj.Status.LastScheduleTime)
+	s.Require().True(cj.Status.LastScheduleTime.After(start))
+}
+
+func (s *Integrationtest) TestCronJob_GetWithoutSuspendFlag() {
+	deleteNamespace, err := testNamespace(s.ctx, "get-cronjob-nil-suspend", s.k8s, false)
+	s.Require().NoError(err)
+	defer deleteNamespace()
+
+	delete, err := createCronJob(s.ctx, *s.k8s, "get-cronjob-nil-suspend", "test-cronjob", nil)
+	s.Require().NoError(err)
+	defer delete()
+
+	actual := s.getSuspendable("get-cronjob-nil-suspend", "2:test-cronjob")
+	s.Require().False(actual.Suspended())
+
+	s.Require().NoError(actual.Suspend(s.ctx))
+	s.Require().True(s.getSuspendable("get-cronjob-nil-suspend", "2:test-cronjob").Suspended())
+}
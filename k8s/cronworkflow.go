@@ -0,0 +1,229 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	kubesleep "github.com/Y0-L0/kubesleep/kubesleep"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var (
+	CRON_WORKFLOW_GVK = schema.GroupVersionKind{Group: "argoproj.io", Version: "v1alpha1", Kind: "CronWorkflow"}
+	WORKFLOW_GVK      = schema.GroupVersionKind{Group: "argoproj.io", Version: "v1alpha1", Kind: "Workflow"}
+)
+
+// cronWorkflowState records the running Workflows of a CronWorkflow that were suspended together with it.
+type cronWorkflowState struct {
+	SuspendedWorkflows []string `json:"suspendedWorkflows,omitempty"`
+}
+
+func (k8s K8Simpl) getCronWorkflows(ctx context.Context, namespace string) (map[string]kubesleep.Suspendable, error) {
+	mapping, err := k8s.mapper.RESTMapping(CRON_WORKFLOW_GVK.GroupKind(), CRON_WORKFLOW_GVK.Version)
+	if meta.IsNoMatchError(err) {
+		slog.Debug("Argo Workflows is not installed; skipping CronWorkflows", "namespace", namespace)
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	cronWorkflows, err := k8s.dynamic.Resource(mapping.Resource).
+		Namespace(namespace).
+		List(ctx, metav1.ListOptions{})
+	if apierrors.IsForbidden(err) {
+		slog.Warn("Missing permissions to list CronWorkflows; skipping them", "namespace", namespace)
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	suspendables := map[string]kubesleep.Suspendable{}
+
+	for _, cronWorkflow := range cronWorkflows.Items {
+		activeWorkflows, err := k8s.getActiveWorkflows(ctx, cronWorkflow)
+		if err != nil {
+			return nil, err
+		}
+
+		// A missing suspend flag defaults to false.
+		suspended, _, err := unstructured.NestedBool(cronWorkflow.Object, "spec", "suspend")
+		if err != nil {
+			return nil, err
+		}
+		var suspend func(context.Context) error
+		if suspended && len(activeWorkflows) == 0 {
+			suspend = k8s.noopSuspendCronWorkflow(namespace, cronWorkflow.GetName())
+		} else {
+			suspend = k8s.suspendCronWorkflow(namespace, mapping.Resource, cronWorkflow.GetName(), activeWorkflows)
+		}
+
+		s := kubesleep.NewSuspendable(
+			kubesleep.CronWorkflow,
+			cronWorkflow.GetName(),
+			0,
+			suspend,
+		).WithSuspended(suspended)
+		if k8s.options.ActiveJobs == kubesleep.SuspendActiveJobs && len(activeWorkflows) > 0 {
+			state, err := json.Marshal(cronWorkflowState{SuspendedWorkflows: activeWorkflows})
+			if err != nil {
+				return nil, err
+			}
+			s = s.WithState(state)
+		}
+		slog.Debug("parsed Suspendable", "Suspendable", s, "namespace", namespace)
+		suspendables[s.Identifier()] = s
+	}
+
+	return suspendables, nil
+}
+
+// getActiveWorkflows returns the running, not yet suspended Workflows of a CronWorkflow
+// if the configured ActiveJobPolicy requires handling them.
+func (k8s K8Simpl) getActiveWorkflows(ctx context.Context, cronWorkflow unstructured.Unstructured) ([]string, error) {
+	if k8s.options.ActiveJobs == kubesleep.KeepActiveJobs {
+		return nil, nil
+	}
+	mapping, err := k8s.mapper.RESTMapping(WORKFLOW_GVK.GroupKind(), WORKFLOW_GVK.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	active, _, err := unstructured.NestedSlice(cronWorkflow.Object, "status", "active")
+	if err != nil {
+		return nil, err
+	}
+
+	var result []string
+	for _, ref := range active {
+		name, _, _ := unstructured.NestedString(ref.(map[string]any), "name")
+		workflow, err := k8s.dynamic.Resource(mapping.Resource).Namespace(cronWorkflow.GetNamespace()).Get(ctx, name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if workflowFinished(*workflow) {
+			continue
+		}
+		if suspended, _, _ := unstructured.NestedBool(workflow.Object, "spec", "suspend"); suspended {
+			continue
+		}
+		result = append(result, name)
+	}
+	return result, nil
+}
+
+// workflowFinished reports whether a Workflow reached one of its final phases.
+func workflowFinished(workflow unstructured.Unstructured) bool {
+	phase, _, _ := unstructured.NestedString(workflow.Object, "status", "phase")
+	return phase == "Succeeded" || phase == "Failed" || phase == "Error"
+}
+
+func (k8s K8Simpl) noopSuspendCronWorkflow(namespace, name string) func(context.Context) error {
+	return func(ctx context.Context) error {
+		slog.Debug("CronWorkflow already suspended; skipping suspend", "namespace", namespace, "name", name)
+		return nil
+	}
+}
+
+func (k8s K8Simpl) suspendCronWorkflow(namespace string, resource schema.GroupVersionResource, name string, activeWorkflows []string) func(context.Context) error {
+	return func(ctx context.Context) error {
+		if err := k8s.setCronWorkflowSuspended(ctx, namespace, resource, name, true); err != nil {
+			return err
+		}
+		for _, workflow := range activeWorkflows {
+			if err := k8s.stopActiveWorkflow(ctx, namespace, workflow); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// stopActiveWorkflow suspends or terminates a running Workflow. Argo suspends Workflows between steps,
+// so running steps finish first. Terminated Workflows are kept and marked as failed by Argo.
+func (k8s K8Simpl) stopActiveWorkflow(ctx context.Context, namespace, name string) error {
+	var patch []byte
+	switch k8s.options.ActiveJobs {
+	case kubesleep.SuspendActiveJobs:
+		patch = []byte(`{"spec":{"suspend":true}}`)
+	case kubesleep.TerminateActiveJobs:
+		patch = []byte(`{"spec":{"shutdown":"Terminate"}}`)
+	default:
+		panic(fmt.Sprintf("unexpected active jobs policy %q", k8s.options.ActiveJobs))
+	}
+
+	err := k8s.patchWorkflow(ctx, namespace, name, patch)
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	slog.Info("Stopped active Workflow of CronWorkflow", "name", name, "namespace", namespace, "policy", k8s.options.ActiveJobs)
+	return nil
+}
+
+func (k8s K8Simpl) patchWorkflow(ctx context.Context, namespace, name string, patch []byte) error {
+	mapping, err := k8s.mapper.RESTMapping(WORKFLOW_GVK.GroupKind(), WORKFLOW_GVK.Version)
+	if err != nil {
+		return err
+	}
+	_, err = k8s.dynamic.Resource(mapping.Resource).Namespace(namespace).Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{})
+	return err
+}
+
+func (k8s K8Simpl) scaleCronWorkflow(ctx context.Context, namespace string, suspendable kubesleep.Suspendable) error {
+	var state cronWorkflowState
+	if suspendable.State() != nil {
+		if err := json.Unmarshal(suspendable.State(), &state); err != nil {
+			return fmt.Errorf("invalid CronWorkflow state: %w", err)
+		}
+	}
+
+	for _, workflow := range state.SuspendedWorkflows {
+		err := k8s.patchWorkflow(ctx, namespace, workflow, []byte(`{"spec":{"suspend":null}}`))
+		if apierrors.IsNotFound(err) {
+			slog.Warn("Workflow was deleted while the namespace was suspended; skipping resume", "name", workflow, "namespace", namespace)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		slog.Info("Resumed Workflow of CronWorkflow", "name", workflow, "namespace", namespace)
+	}
+
+	mapping, err := k8s.mapper.RESTMapping(CRON_WORKFLOW_GVK.GroupKind(), CRON_WORKFLOW_GVK.Version)
+	if err != nil {
+		return err
+	}
+	return k8s.setCronWorkflowSuspended(ctx, namespace, mapping.Resource, suspendable.Name(), suspendable.Suspended())
+}
+
+func (k8s K8Simpl) setCronWorkflowSuspended(ctx context.Context, namespace string, resource schema.GroupVersionResource, name string, suspended bool) error {
+	patch, err := json.Marshal(map[string]any{"spec": map[string]any{"suspend": suspended}})
+	if err != nil {
+		return err
+	}
+
+	_, err = k8s.dynamic.Resource(resource).Namespace(namespace).Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{})
+	if err != nil {
+		return err
+	}
+	if suspended {
+		slog.Info("Suspended CronWorkflow", "name", name, "namespace", namespace)
+	} else {
+		slog.Info("Woke up CronWorkflow", "name", name, "namespace", namespace)
+	}
+	return nil
+}
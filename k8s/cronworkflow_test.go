@@ -0,0 +1,137 @@
+package k8s
+
+import (
+	kubesleep "github.com/Y0-L0/kubesleep/kubesleep"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const CRON_WORKFLOW_KEY = "19:test-cronworkflow"
+
+// createActiveCronWorkflow creates a CronWorkflow with a running Workflow listed in its status,
+// standing in for the Argo controller that is not running in the test control plane.
+func (s *Integrationtest) createActiveCronWorkflow(namespace string) func() {
+	deleteCronWorkflow, err := CreateCustomResource(s.ctx, *s.k8s, CRON_WORKFLOW_GVR, "CronWorkflow", namespace, "test-cronworkflow", map[string]any{"schedule": "0 2 * * *"})
+	s.Require().NoError(err)
+	deleteWorkflow, err := CreateCustomResource(s.ctx, *s.k8s, WORKFLOW_GVR, "Workflow", namespace, "test-workflow", map[string]any{})
+	s.Require().NoError(err)
+
+	workflow := s.getObject(WORKFLOW_GVR, namespace, "test-workflow")
+	s.Require().NoError(unstructured.SetNestedField(workflow.Object, "Running", "status", "phase"))
+	_, err = s.k8s.dynamic.Resource(WORKFLOW_GVR).Namespace(namespace).Update(s.ctx, workflow, metav1.UpdateOptions{})
+	s.Require().NoError(err)
+
+	cronWorkflow := s.getObject(CRON_WORKFLOW_GVR, namespace, "test-cronworkflow")
+	s.Require().NoError(unstructured.SetNestedSlice(cronWorkflow.Object, []any{map[string]any{"name": "test-workflow"}}, "status", "active"))
+	_, err = s.k8s.dynamic.Resource(CRON_WORKFLOW_GVR).Namespace(namespace).Update(s.ctx, cronWorkflow, metav1.UpdateOptions{})
+	s.Require().NoError(err)
+
+	return func() {
+		deleteWorkflow()
+		deleteCronWorkflow()
+	}
+}
+
+func (s *Integrationtest) getObject(gvr schema.GroupVersionResource, namespace, name string) *unstructured.Unstructured {
+	object, err := s.k8s.dynamic.Resource(gvr).Namespace(namespace).Get(s.ctx, name, metav1.GetOptions{})
+	s.Require().NoError(err)
+	return object
+}
+
+func (s *Integrationtest) getSpecSuspend(gvr schema.GroupVersionResource, namespace, name string) (bool, bool) {
+	suspended, found, err := unstructured.NestedBool(s.getObject(gvr, namespace, name).Object, "spec", "suspend")
+	s.Require().NoError(err)
+	return suspended, found
+}
+
+func (s *Integrationtest) TestCronWorkflow_SuspendAndWake() {
+	deleteNamespace, err := testNamespace(s.ctx, "suspend-cronworkflows", s.k8s, false)
+	s.Require().NoError(err)
+	defer deleteNamespace()
+	delete, err := CreateCustomResource(s.ctx, *s.k8s, CRON_WORKFLOW_GVR, "CronWorkflow", "suspend-cronworkflows", "test-cronworkflow", map[string]any{"schedule": "0 2 * * *"})
+	s.Require().NoError(err)
+	defer delete()
+
+	before := s.getSuspendable("suspend-cronworkflows", CRON_WORKFLOW_KEY)
+	s.Require().False(before.Suspended())
+	s.Require().NoError(before.Suspend(s.ctx))
+	s.Require().True(s.getSuspendable("suspend-cronworkflows", CRON_WORKFLOW_KEY).Suspended())
+
+	s.Require().NoError(s.k8s.ScaleSuspendable(s.ctx, "suspend-cronworkflows", before))
+	s.Require().False(s.getSuspendable("suspend-cronworkflows", CRON_WORKFLOW_KEY).Suspended())
+}
+
+func (s *Integrationtest) TestCronWorkflow_StaysSuspended() {
+	deleteNamespace, err := testNamespace(s.ctx, "suspended-cronworkflows", s.k8s, false)
+	s.Require().NoError(err)
+	defer deleteNamespace()
+	delete, err := CreateCustomResource(s.ctx, *s.k8s, CRON_WORKFLOW_GVR, "CronWorkflow", "suspended-cronworkflows", "test-cronworkflow", map[string]any{"schedule": "0 2 * * *", "suspend": true})
+	s.Require().NoError(err)
+	defer delete()
+
+	before := s.getSuspendable("suspended-cronworkflows", CRON_WORKFLOW_KEY)
+	s.Require().True(before.Suspended())
+	s.Require().NoError(before.Suspend(s.ctx))
+
+	s.Require().NoError(s.k8s.ScaleSuspendable(s.ctx, "suspended-cronworkflows", before))
+	s.Require().True(s.getSuspendable("suspended-cronworkflows", CRON_WORKFLOW_KEY).Suspended())
+}
+
+func (s *Integrationtest) TestCronWorkflow_SuspendActiveWorkflows() {
+	deleteNamespace, err := testNamespace(s.ctx, "suspend-active-workflows", s.k8s, false)
+	s.Require().NoError(err)
+	defer deleteNamespace()
+	defer s.createActiveCronWorkflow("suspend-active-workflows")()
+
+	k8s := *s.k8s
+	k8s.options.ActiveJobs = kubesleep.SuspendActiveJobs
+
+	suspendables, err := k8s.GetSuspendables(s.ctx, "suspend-active-workflows")
+	s.Require().NoError(err)
+	before := suspendables[CRON_WORKFLOW_KEY]
+	s.Require().JSONEq(`{"suspendedWorkflows": ["test-workflow"]}`, string(before.State()))
+	s.Require().NoError(before.Suspend(s.ctx))
+
+	suspended, _ := s.getSpecSuspend(WORKFLOW_GVR, "suspend-active-workflows", "test-workflow")
+	s.Require().True(suspended)
+
+	s.Require().NoError(k8s.ScaleSuspendable(s.ctx, "suspend-active-workflows", before))
+
+	_, found := s.getSpecSuspend(WORKFLOW_GVR, "suspend-active-workflows", "test-workflow")
+	s.Require().False(found)
+}
+
+func (s *Integrationtest) TestCronWorkflow_TerminateActiveWorkflows() {
+	deleteNamespace, err := testNamespace(s.ctx, "terminate-active-workflows", s.k8s, false)
+	s.Require().NoError(err)
+	defer deleteNamespace()
+	defer s.createActiveCronWorkflow("terminate-active-workflows")()
+
+	k8s := *s.k8s
+	k8s.options.ActiveJobs = kubesleep.TerminateActiveJobs
+
+	suspendables, err := k8s.GetSuspendables(s.ctx, "terminate-active-workflows")
+	s.Require().NoError(err)
+	before := suspendables[CRON_WORKFLOW_KEY]
+	s.Require().Nil(before.State())
+	s.Require().NoError(before.Suspend(s.ctx))
+
+	workflow := s.getObject(WORKFLOW_GVR, "terminate-active-workflows", "test-workflow")
+	shutdown, _, err := unstructured.NestedString(workflow.Object, "spec", "shutdown")
+	s.Require().NoError(err)
+	s.Require().Equal("Terminate", shutdown)
+}
+
+func (s *Unittest) TestWorkflowFinished() {
+	workflow := func(phase string) unstructured.Unstructured {
+		return unstructured.Unstructured{Object: map[string]any{"status": map[string]any{"phase": phase}}}
+	}
+
+	s.False(workflowFinished(unstructured.Unstructured{Object: map[string]any{}}))
+	s.False(workflowFinished(workflow("Running")))
+	s.False(workflowFinished(workflow("Pending")))
+	s.True(workflowFinished(workflow("Succeeded")))
+	s.True(workflowFinished(workflow("Failed")))
+	s.True(workflowFinished(workflow("Error")))
+}
@@ -0,0 +1,136 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"maps"
+
+	kubesleep "github.com/Y0-L0/kubesleep/kubesleep"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SUSPENDED_NODE_SELECTOR is added to the pod template of suspended DaemonSets.
+// No node carries this label, so the DaemonSet controller removes all pods.
+const SUSPENDED_NODE_SELECTOR = "kubesleep.xyz/suspended"
+
+type daemonSetState struct {
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+}
+
+func (k8s K8Simpl) getDaemonSets(ctx context.Context, namespace string) (map[string]kubesleep.Suspendable, error) {
+	daemonSets, err := k8s.clientset.AppsV1().
+		DaemonSets(namespace).
+		List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	suspendables := map[string]kubesleep.Suspendable{}
+
+	for _, daemonSet := range daemonSets.Items {
+		nodeSelector := maps.Clone(daemonSet.Spec.Template.Spec.NodeSelector)
+
+		var suspend func(context.Context) error
+		if _, suspended := nodeSelector[SUSPENDED_NODE_SELECTOR]; suspended {
+			suspend = k8s.noopSuspendDaemonSet(namespace, daemonSet.Name)
+			delete(nodeSelector, SUSPENDED_NODE_SELECTOR)
+		} else {
+			suspend = k8s.suspendDaemonSet(namespace, daemonSet.Name)
+		}
+
+		state, err := json.Marshal(daemonSetState{NodeSelector: nodeSelector})
+		if err != nil {
+			return nil, err
+		}
+
+		s := kubesleep.NewSuspendable(
+			kubesleep.DaemonSet,
+			daemonSet.Name,
+			daemonSet.Status.CurrentNumberScheduled,
+			suspend,
+		).WithState(state)
+		slog.Debug("parsed Suspendable", "Suspendable", s, "namespace", namespace)
+		suspendables[s.Identifier()] = s
+	}
+
+	return suspendables, nil
+}
+
+func (k8s K8Simpl) noopSuspendDaemonSet(namespace, name string) func(context.Context) error {
+	return func(ctx context.Context) error {
+		slog.Debug("DaemonSet already has the suspended node selector; skipping suspend", "namespace", namespace, "name", name)
+		return nil
+	}
+}
+
+func (k8s K8Simpl) suspendDaemonSet(namespace string, name string) func(context.Context) error {
+	return func(ctx context.Context) error {
+		daemonSet, err := k8s.clientset.AppsV1().
+			DaemonSets(namespace).
+			Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		if daemonSet.Spec.Template.Spec.NodeSelector == nil {
+			daemonSet.Spec.Template.Spec.NodeSelector = map[string]string{}
+		}
+		daemonSet.Spec.Template.Spec.NodeSelector[SUSPENDED_NODE_SELECTOR] = "true"
+
+		_, err = k8s.clientset.AppsV1().DaemonSets(namespace).Update(
+			ctx,
+			daemonSet,
+			metav1.UpdateOptions{},
+		)
+		if err != nil {
+			return err
+		}
+		slog.Info("Suspended DaemonSet", "name", name, "namespace", namespace)
+		return nil
+	}
+}
+
+func (k8s K8Simpl) wakeDaemonSet(ctx context.Context, namespace string, suspendable kubesleep.Suspendable) error {
+	var state daemonSetState
+	if err := json.Unmarshal(suspendable.State(), &state); err != nil {
+		return fmt.Errorf("invalid DaemonSet state: %w", err)
+	}
+
+	daemonSet, err := k8s.clientset.AppsV1().
+		DaemonSets(namespace).
+		Get(ctx, suspendable.Name(), metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	daemonSet.Spec.Template.Spec.NodeSelector = restoreNodeSelector(daemonSet.Spec.Template.Spec.NodeSelector, state.NodeSelector)
+
+	_, err = k8s.clientset.AppsV1().DaemonSets(namespace).Update(
+		ctx,
+		daemonSet,
+		metav1.UpdateOptions{},
+	)
+	if err != nil {
+		return err
+	}
+
+	slog.Info("Woke up DaemonSet", "name", suspendable.Name(), "namespace", namespace)
+	return nil
+}
+
+// restoreNodeSelector removes the suspended node selector and restores the original entries.
+// Entries added while the DaemonSet was suspended are kept.
+func restoreNodeSelector(current, original map[string]string) map[string]string {
+	result := maps.Clone(current)
+	delete(result, SUSPENDED_NODE_SELECTOR)
+	if result == nil {
+		result = map[string]string{}
+	}
+	maps.Copy(result, original)
+	if len(result) == 0 {
+		return nil
+	}
+	return result
+}
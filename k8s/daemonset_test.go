@@ -0,0 +1,125 @@
+package k8s
+
+import (
+	"context"
+
+	kubesleep "github.com/Y0-L0/kubesleep/kubesleep"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func CreateDaemonSet(ctx context.Context, k8s K8Simpl, namespace string, name string, nodeSelector map[string]string) (func() error, error) {
+	labels := map[string]string{"app": name}
+
+	daemonSet := &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: appsv1.DaemonSetSpec{
+			Selector: &metav1.LabelSelector{
+				MatchLabels: labels,
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: corev1.PodSpec{
+					NodeSelector: nodeSelector,
+					Containers: []corev1.Container{
+						{
+							Name:  name,
+							Image: "k8s.gcr.io/pause:3.9",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	_, err := k8s.clientset.AppsV1().DaemonSets(namespace).Create(ctx, daemonSet, metav1.CreateOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	delete := func() error {
+		return k8s.clientset.AppsV1().DaemonSets(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+	}
+	return delete, nil
+}
+
+func (s *Integrationtest) TestDaemonSet_Get() {
+	deleteNamespace, err := testNamespace(s.ctx, "get-daemonsets", s.k8s, false)
+	s.Require().NoError(err)
+	defer deleteNamespace()
+
+	delete, err := CreateDaemonSet(s.ctx, *s.k8s, "get-daemonsets", "test-daemonset", map[string]string{"disk": "ssd"})
+	s.Require().NoError(err)
+	defer delete()
+
+	actual := s.getSuspendable("get-daemonsets", "5:test-daemonset")
+	actual.Suspend = nil
+	s.Require().Equal(
+		kubesleep.NewSuspendable(
+			kubesleep.DaemonSet,
+			"test-daemonset",
+			int32(0),
+			nil,
+		).WithState([]byte(`{"nodeSelector":{"disk":"ssd"}}`)),
+		actual,
+	)
+}
+
+func (s *Integrationtest) TestDaemonSet_SuspendAndWake() {
+	deleteNamespace, err := testNamespace(s.ctx, "suspend-daemonsets", s.k8s, false)
+	s.Require().NoError(err)
+	defer deleteNamespace()
+
+	delete, err := CreateDaemonSet(s.ctx, *s.k8s, "suspend-daemonsets", "test-daemonset", map[string]string{"disk": "ssd"})
+	s.Require().NoError(err)
+	defer delete()
+
+	before := s.getSuspendable("suspend-daemonsets", "5:test-daemonset")
+	s.Require().NoError(before.Suspend(s.ctx))
+
+	suspended, err := s.k8s.clientset.AppsV1().DaemonSets("suspend-daemonsets").Get(s.ctx, "test-daemonset", metav1.GetOptions{})
+	s.Require().NoError(err)
+	s.Require().Equal(
+		map[string]string{"disk": "ssd", SUSPENDED_NODE_SELECTOR: "true"},
+		suspended.Spec.Template.Spec.NodeSelector,
+	)
+
+	// A repeated suspend records the original node selector and doesn't touch the DaemonSet
+	again := s.getSuspendable("suspend-daemonsets", "5:test-daemonset")
+	s.Require().Equal(before.State(), again.State())
+	s.Require().NoError(again.Suspend(s.ctx))
+
+	err = s.k8s.ScaleSuspendable(s.ctx, "suspend-daemonsets", before)
+	s.Require().NoError(err)
+
+	woken, err := s.k8s.clientset.AppsV1().DaemonSets("suspend-daemonsets").Get(s.ctx, "test-daemonset", metav1.GetOptions{})
+	s.Require().NoError(err)
+	s.Require().Equal(map[string]string{"disk": "ssd"}, woken.Spec.Template.Spec.NodeSelector)
+}
+
+func (s *Unittest) TestRestoreNodeSelector() {
+	tests := []struct {
+		name     string
+		current  map[string]string
+		original map[string]string
+		expected map[string]string
+	}{
+		{"no selector", map[string]string{SUSPENDED_NODE_SELECTOR: "true"}, nil, nil},
+		{"original selector", map[string]string{"disk": "ssd", SUSPENDED_NODE_SELECTOR: "true"}, map[string]string{"disk": "ssd"}, map[string]string{"disk": "ssd"}},
+		{"keeps new entries", map[string]string{"zone": "a", SUSPENDED_NODE_SELECTOR: "true"}, map[string]string{"disk": "ssd"}, map[string]string{"disk": "ssd", "zone": "a"}},
+		{"restores modified entries", map[string]string{"disk": "hdd", SUSPENDED_NODE_SELECTOR: "true"}, map[string]string{"disk": "ssd"}, map[string]string{"disk": "ssd"}},
+		{"nil current", nil, map[string]string{"disk": "ssd"}, map[string]string{"disk": "ssd"}},
+	}
+
+	for _, testCase := range tests {
+		s.Run(testCase.name, func() {
+			s.Require().Equal(testCase.expected, restoreNodeSelector(testCase.current, testCase.original))
+		})
+	}
+}
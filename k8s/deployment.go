@@ -6,6 +6,7 @@ import (
 
 	kubesleep "github.com/Y0-L0/kubesleep/kubesleep"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 )
 
 func (k8s K8Simpl) getDeployments(ctx context.Context, namespace string) (map[string]kubesleep.Suspendable, error) {
@@ -19,19 +20,32 @@ func (k8s K8Simpl) getDeployments(ctx context.Context, namespace string) (map[st
 	suspendables := map[string]kubesleep.Suspendable{}
 
 	for _, deployment := range deployments.Items {
+		if owner := metav1.GetControllerOf(&deployment); owner != nil {
+			s := k8s.operatorManagedSuspendable(namespace, "apps/v1", "Deployment", deployment.Name, *owner)
+			suspendables[s.Identifier()] = s
+			continue
+		}
+		// The API server defaults replicas to 1 when they are not set.
+		replicas := ptr.Deref(deployment.Spec.Replicas, 1)
 		var suspend func(context.Context) error
-		if deployment.Spec.Replicas != nil && *deployment.Spec.Replicas == 0 {
+		if replicas == 0 {
 			suspend = k8s.noopSuspendDeployment(namespace, deployment.Name)
 		} else {
 			suspend = k8s.suspendDeployment(namespace, deployment.Name)
 		}
 
-		s := kubesleep.NewSuspendable(
+		s, included, err := k8s.withDownscaler(namespace, DEPLOYMENTS_GVR, &deployment, kubesleep.NewSuspendable(
 			kubesleep.Deplyoment,
 			deployment.Name,
-			*deployment.Spec.Replicas,
+			replicas,
 			suspend,
-		)
+		))
+		if err != nil {
+			return nil, err
+		}
+		if !included {
+			continue
+		}
 		slog.Debug("parsed Suspendable", "Suspendable", s, "namespace", namespace)
 		suspendables[s.Identifier()] = s
 	}
@@ -8,13 +8,14 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
-func CreateDeployment(ctx context.Context, k8s K8Simpl, namespace string, name string, Replicas int32) (func() error, error) {
+func CreateDeployment(ctx context.Context, k8s K8Simpl, namespace string, name string, Replicas int32, owners ...metav1.OwnerReference) (func() error, error) {
 	labels := map[string]string{"app": name}
 
 	deployment := &appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      name,
-			Namespace: namespace,
+			Name:            name,
+			Namespace:       namespace,
+			OwnerReferences: owners,
 		},
 		Spec: appsv1.DeploymentSpec{
 			Replicas: &Replicas,
@@ -129,7 +130,7 @@ func (s *Integrationtest) TestScaleDeployment() {
 	s.Require().NoError(err)
 	defer delete()
 
-	err = s.k8s.ScaleSuspendable(s.ctx, "scale-deployments", kubesleep.Deplyoment, "test-deployment", int32(2))
+	err = s.k8s.ScaleSuspendable(s.ctx, "scale-deployments", kubesleep.NewSuspendable(kubesleep.Deplyoment, "test-deployment", int32(2), nil))
 
 	actual := s.getSuspendable("scale-deployments", "0:test-deployment")
 	s.Require().Equal(int32(2), actual.Replicas)
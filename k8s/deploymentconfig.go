@@ -0,0 +1,97 @@
+package k8s
+
+import (
+	"context"
+	"log/slog"
+
+	kubesleep "github.com/Y0-L0/kubesleep/kubesleep"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var DEPLOYMENT_CONFIG_GVK = schema.GroupVersionKind{Group: "apps.openshift.io", Version: "v1", Kind: "DeploymentConfig"}
+
+// getDeploymentConfigs returns the OpenShift DeploymentConfigs of the namespace. They are only looked up
+// when the apps.openshift.io API group is served by the cluster.
+func (k8s K8Simpl) getDeploymentConfigs(ctx context.Context, namespace string) (map[string]kubesleep.Suspendable, error) {
+	mapping, err := k8s.mapper.RESTMapping(DEPLOYMENT_CONFIG_GVK.GroupKind(), DEPLOYMENT_CONFIG_GVK.Version)
+	if meta.IsNoMatchError(err) {
+		slog.Debug("OpenShift DeploymentConfigs are not available; skipping them", "namespace", namespace)
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	deploymentConfigs, err := k8s.dynamic.Resource(mapping.Resource).
+		Namespace(namespace).
+		List(ctx, metav1.ListOptions{})
+	if apierrors.IsForbidden(err) {
+		slog.Warn("Missing permissions to list DeploymentConfigs; skipping them", "namespace", namespace)
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	suspendables := map[string]kubesleep.Suspendable{}
+
+	for _, deploymentConfig := range deploymentConfigs.Items {
+		scalable, err := k8s.scales.Scales(namespace).Get(ctx, mapping.Resource.GroupResource(), deploymentConfig.GetName(), metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+
+		var suspend func(context.Context) error
+		if scalable.Spec.Replicas == 0 {
+			suspend = k8s.noopSuspendDeploymentConfig(namespace, deploymentConfig.GetName())
+		} else {
+			suspend = k8s.suspendDeploymentConfig(namespace, mapping.Resource.GroupResource(), deploymentConfig.GetName())
+		}
+
+		s := kubesleep.NewSuspendable(
+			kubesleep.DeploymentConfig,
+			deploymentConfig.GetName(),
+			scalable.Spec.Replicas,
+			suspend,
+		)
+		slog.Debug("parsed Suspendable", "Suspendable", s, "namespace", namespace)
+		suspendables[s.Identifier()] = s
+	}
+
+	return suspendables, nil
+}
+
+func (k8s K8Simpl) noopSuspendDeploymentConfig(namespace, name string) func(context.Context) error {
+	return func(ctx context.Context) error {
+		slog.Debug("DeploymentConfig already at 0 replicas; skipping suspend", "namespace", namespace, "name", name)
+		return nil
+	}
+}
+
+// suspendDeploymentConfig only changes the replicas. ConfigChange triggers fire on pod template changes,
+// so scaling doesn't roll out a new deployment.
+func (k8s K8Simpl) suspendDeploymentConfig(namespace string, resource schema.GroupResource, name string) func(context.Context) error {
+	return func(ctx context.Context) error {
+		if err := k8s.updateScale(ctx, namespace, resource, name, 0); err != nil {
+			return err
+		}
+		slog.Info("Suspended DeploymentConfig", "name", name, "namespace", namespace)
+		return nil
+	}
+}
+
+func (k8s K8Simpl) scaleDeploymentConfig(ctx context.Context, namespace string, name string, replicas int32) error {
+	mapping, err := k8s.mapper.RESTMapping(DEPLOYMENT_CONFIG_GVK.GroupKind(), DEPLOYMENT_CONFIG_GVK.Version)
+	if err != nil {
+		return err
+	}
+
+	if err := k8s.updateScale(ctx, namespace, mapping.Resource.GroupResource(), name, replicas); err != nil {
+		return err
+	}
+	slog.Info("Woke up DeploymentConfig", "namespace", namespace, "name", name)
+	return nil
+}
@@ -0,0 +1,53 @@
+package k8s
+
+import (
+	kubesleep "github.com/Y0-L0/kubesleep/kubesleep"
+)
+
+func (s *Integrationtest) TestDeploymentConfig_Get() {
+	deleteNamespace, err := testNamespace(s.ctx, "get-deploymentconfigs", s.k8s, false)
+	s.Require().NoError(err)
+	defer deleteNamespace()
+
+	delete, err := CreateCustomResource(s.ctx, *s.k8s, DEPLOYMENT_CONFIG_GVR, "DeploymentConfig", "get-deploymentconfigs", "test-dc", map[string]any{"replicas": int64(3)})
+	s.Require().NoError(err)
+	defer delete()
+
+	suspendables, err := s.k8s.GetSuspendables(s.ctx, "get-deploymentconfigs")
+	s.Require().NoError(err)
+	s.Require().NotContains(suspendables, "3:DeploymentConfig.apps.openshift.io:test-dc", "DeploymentConfigs must not be suspended twice")
+
+	actual := suspendables["18:test-dc"]
+	actual.Suspend = nil
+	s.Require().Equal(
+		kubesleep.NewSuspendable(
+			kubesleep.DeploymentConfig,
+			"test-dc",
+			int32(3),
+			nil,
+		),
+		actual,
+	)
+}
+
+func (s *Integrationtest) TestDeploymentConfig_SuspendAndScale() {
+	deleteNamespace, err := testNamespace(s.ctx, "suspend-deploymentconfigs", s.k8s, false)
+	s.Require().NoError(err)
+	defer deleteNamespace()
+
+	delete, err := CreateCustomResource(s.ctx, *s.k8s, DEPLOYMENT_CONFIG_GVR, "DeploymentConfig", "suspend-deploymentconfigs", "test-dc", map[string]any{"replicas": int64(3)})
+	s.Require().NoError(err)
+	defer delete()
+
+	before := s.getSuspendable("suspend-deploymentconfigs", "18:test-dc")
+	s.Require().NoError(before.Suspend(s.ctx))
+
+	suspended := s.getSuspendable("suspend-deploymentconfigs", "18:test-dc")
+	s.Require().Equal(int32(0), suspended.Replicas)
+
+	err = s.k8s.ScaleSuspendable(s.ctx, "suspend-deploymentconfigs", before)
+	s.Require().NoError(err)
+
+	actual := s.getSuspendable("suspend-deploymentconfigs", "18:test-dc")
+	s.Require().Equal(int32(3), actual.Replicas)
+}
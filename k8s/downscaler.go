@@ -0,0 +1,92 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strconv"
+
+	kubesleep "github.com/Y0-L0/kubesleep/kubesleep"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// Annotations of kube-downscaler (https://codeberg.org/hjacobs/kube-downscaler).
+const (
+	downscalerOriginalReplicas = "downscaler/original-replicas"
+	downscalerExclude          = "downscaler/exclude"
+)
+
+var (
+	DEPLOYMENTS_GVR  = schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+	STATEFULSETS_GVR = schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "statefulsets"}
+)
+
+// downscalerState marks a workload whose downscaler/original-replicas annotation was imported or written by kubesleep.
+// The annotation is removed again on wake.
+type downscalerState struct {
+	Annotated bool `json:"downscalerAnnotation"`
+}
+
+// withDownscaler applies the kube-downscaler options to a Deployment or StatefulSet suspendable.
+// In import mode, workloads at 0 replicas report the replicas recorded by kube-downscaler and excluded workloads
+// are skipped. The returned bool is false for excluded workloads.
+func (k8s K8Simpl) withDownscaler(namespace string, resource schema.GroupVersionResource, object metav1.Object, s kubesleep.Suspendable) (kubesleep.Suspendable, bool, error) {
+	annotations := object.GetAnnotations()
+	annotated := false
+
+	if k8s.options.DownscalerImport {
+		if annotations[downscalerExclude] == "true" {
+			slog.Info("Skipping workload excluded from kube-downscaler", "resource", resource.Resource, "name", object.GetName(), "namespace", namespace)
+			return s, false, nil
+		}
+
+		if value, ok := annotations[downscalerOriginalReplicas]; ok && s.Replicas == 0 {
+			replicas, err := strconv.ParseInt(value, 10, 32)
+			if err != nil || replicas < 0 {
+				slog.Warn("Ignoring invalid kube-downscaler annotation", "resource", resource.Resource, "name", object.GetName(), "namespace", namespace, "value", value)
+			} else {
+				slog.Debug("Imported replicas from kube-downscaler annotation", "resource", resource.Resource, "name", object.GetName(), "namespace", namespace, "replicas", replicas)
+				s.Replicas = int32(replicas)
+				annotated = true
+			}
+		}
+	}
+
+	if k8s.options.DownscalerAnnotations && s.Replicas > 0 {
+		suspend, value := s.Suspend, strconv.Itoa(int(s.Replicas))
+		s.Suspend = func(ctx context.Context) error {
+			if err := k8s.patchAnnotation(ctx, namespace, resource, object.GetName(), downscalerOriginalReplicas, &value); err != nil {
+				return err
+			}
+			return suspend(ctx)
+		}
+		annotated = true
+	}
+
+	if annotated {
+		state, err := json.Marshal(downscalerState{Annotated: true})
+		if err != nil {
+			return s, false, err
+		}
+		s = s.WithState(state)
+	}
+	return s, true, nil
+}
+
+// removeDownscalerAnnotation removes the downscaler/original-replicas annotation from a woken workload
+// if it was imported or written on suspend.
+func (k8s K8Simpl) removeDownscalerAnnotation(ctx context.Context, namespace string, resource schema.GroupVersionResource, suspendable kubesleep.Suspendable) error {
+	if len(suspendable.State()) == 0 {
+		return nil
+	}
+	var state downscalerState
+	if err := json.Unmarshal(suspendable.State(), &state); err != nil {
+		return fmt.Errorf("invalid downscaler state: %w", err)
+	}
+	if !state.Annotated {
+		return nil
+	}
+	return k8s.patchAnnotation(ctx, namespace, resource, suspendable.Name(), downscalerOriginalReplicas, nil)
+}
@@ -0,0 +1,111 @@
+package k8s
+
+import (
+	kubesleep "github.com/Y0-L0/kubesleep/kubesleep"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func (s *Integrationtest) TestDownscaler_Import() {
+	deleteNamespace, err := testNamespace(s.ctx, "downscaler-import", s.k8s, false)
+	s.Require().NoError(err)
+	defer deleteNamespace()
+
+	_, err = CreateDeployment(s.ctx, *s.k8s, "downscaler-import", "web", 0)
+	s.Require().NoError(err)
+	s.Require().NoError(s.k8s.patchAnnotation(s.ctx, "downscaler-import", DEPLOYMENTS_GVR, "web", downscalerOriginalReplicas, ptr.To("3")))
+	_, err = CreateDeployment(s.ctx, *s.k8s, "downscaler-import", "cache", 0)
+	s.Require().NoError(err)
+	s.Require().NoError(s.k8s.patchAnnotation(s.ctx, "downscaler-import", DEPLOYMENTS_GVR, "cache", downscalerExclude, ptr.To("true")))
+
+	suspendables, err := s.k8s.GetSuspendables(s.ctx, "downscaler-import")
+	s.Require().NoError(err)
+	s.Require().Equal(int32(0), suspendables["0:web"].Replicas)
+	s.Require().Contains(suspendables, "0:cache")
+
+	k8s := *s.k8s
+	k8s.options.DownscalerImport = true
+
+	suspendables, err = k8s.GetSuspendables(s.ctx, "downscaler-import")
+	s.Require().NoError(err)
+	s.Require().NotContains(suspendables, "0:cache")
+	web := suspendables["0:web"]
+	s.Require().Equal(int32(3), web.Replicas)
+	s.Require().NoError(web.Suspend(s.ctx))
+
+	s.Require().NoError(k8s.ScaleSuspendable(s.ctx, "downscaler-import", web))
+
+	deployment, err := s.k8s.clientset.AppsV1().Deployments("downscaler-import").Get(s.ctx, "web", metav1.GetOptions{})
+	s.Require().NoError(err)
+	s.Require().Equal(int32(3), *deployment.Spec.Replicas)
+	s.Require().NotContains(deployment.Annotations, downscalerOriginalReplicas)
+}
+
+func (s *Integrationtest) TestDownscaler_WriteAnnotations() {
+	deleteNamespace, err := testNamespace(s.ctx, "downscaler-annotations", s.k8s, false)
+	s.Require().NoError(err)
+	defer deleteNamespace()
+
+	_, err = CreateStatefulSet(s.ctx, *s.k8s, "downscaler-annotations", "db", 2)
+	s.Require().NoError(err)
+
+	k8s := *s.k8s
+	k8s.options.DownscalerAnnotations = true
+
+	suspendables, err := k8s.GetSuspendables(s.ctx, "downscaler-annotations")
+	s.Require().NoError(err)
+	db := suspendables["1:db"]
+	s.Require().NoError(db.Suspend(s.ctx))
+
+	s.Require().Equal("2", s.getAnnotations(STATEFULSETS_GVR, "downscaler-annotations", "db")[downscalerOriginalReplicas])
+	statefulSet, err := s.k8s.clientset.AppsV1().StatefulSets("downscaler-annotations").Get(s.ctx, "db", metav1.GetOptions{})
+	s.Require().NoError(err)
+	s.Require().Equal(int32(0), *statefulSet.Spec.Replicas)
+
+	s.Require().NoError(s.k8s.ScaleSuspendable(s.ctx, "downscaler-annotations", db))
+
+	s.Require().NotContains(s.getAnnotations(STATEFULSETS_GVR, "downscaler-annotations", "db"), downscalerOriginalReplicas)
+}
+
+func (s *Unittest) TestWithDownscaler() {
+	object := func(annotations map[string]string) metav1.Object {
+		return &metav1.ObjectMeta{Name: "web", Annotations: annotations}
+	}
+	k8s := K8Simpl{options: kubesleep.K8SOptions{DownscalerImport: true}}
+
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		replicas    int32
+		expected    int32
+		included    bool
+		state       string
+	}{
+		{"not annotated", nil, 2, 2, true, ""},
+		{"imported", map[string]string{downscalerOriginalReplicas: "3"}, 0, 3, true, `{"downscalerAnnotation":true}`},
+		{"running workloads keep their replicas", map[string]string{downscalerOriginalReplicas: "3"}, 1, 1, true, ""},
+		{"invalid annotation", map[string]string{downscalerOriginalReplicas: "three"}, 0, 0, true, ""},
+		{"excluded", map[string]string{downscalerExclude: "true"}, 2, 2, false, ""},
+		{"exclude disabled", map[string]string{downscalerExclude: "false"}, 2, 2, true, ""},
+	}
+
+	for _, testCase := range tests {
+		s.Run(testCase.name, func() {
+			actual, included, err := k8s.withDownscaler("dev", DEPLOYMENTS_GVR, object(testCase.annotations), kubesleep.NewSuspendable(kubesleep.Deplyoment, "web", testCase.replicas, nil))
+
+			s.Require().NoError(err)
+			s.Require().Equal(testCase.included, included)
+			s.Require().Equal(testCase.expected, actual.Replicas)
+			s.Require().Equal(testCase.state, string(actual.State()))
+		})
+	}
+
+	k8s.options = kubesleep.K8SOptions{DownscalerAnnotations: true}
+	actual, _, err := k8s.withDownscaler("dev", DEPLOYMENTS_GVR, object(nil), kubesleep.NewSuspendable(kubesleep.Deplyoment, "web", 0, nil))
+	s.Require().NoError(err)
+	s.Require().Empty(actual.State(), "workloads at 0 replicas are not annotated")
+	actual, _, err = k8s.withDownscaler("dev", DEPLOYMENTS_GVR, object(map[string]string{downscalerExclude: "true"}), kubesleep.NewSuspendable(kubesleep.Deplyoment, "web", 2, nil))
+	s.Require().NoError(err)
+	s.Require().Equal(`{"downscalerAnnotation":true}`, string(actual.State()), "exclusion is only honoured on import")
+	s.Require().NotNil(actual.Suspend)
+}
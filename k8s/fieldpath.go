@@ -0,0 +1,183 @@
+package k8s
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// fieldPathSegment is either an object field, an array index or an array wildcard.
+type fieldPathSegment struct {
+	field    string
+	index    int
+	isIndex  bool
+	wildcard bool
+}
+
+// fieldPath is the parsed form of the JSONPath subset supported by field rules.
+type fieldPath []fieldPathSegment
+
+var (
+	fieldPathPart    = regexp.MustCompile(`^([^.\[\]]+)((?:\[(?:\d+|\*)\])*)$`)
+	fieldPathIndexes = regexp.MustCompile(`\[(\d+|\*)\]`)
+)
+
+// parseFieldPath parses paths like .spec.suspend, {.spec.runStrategy} or .spec.nodePools[*].replicas.
+func parseFieldPath(path string) (fieldPath, error) {
+	trimmed := strings.TrimSuffix(strings.TrimPrefix(path, "{"), "}")
+	trimmed = strings.TrimPrefix(trimmed, ".")
+	if trimmed == "" {
+		return nil, fmt.Errorf("invalid field path %q: path is empty", path)
+	}
+
+	var result fieldPath
+	for _, part := range strings.Split(trimmed, ".") {
+		match := fieldPathPart.FindStringSubmatch(part)
+		if match == nil {
+			return nil, fmt.Errorf("invalid field path %q: unsupported segment %q", path, part)
+		}
+		result = append(result, fieldPathSegment{field: match[1]})
+
+		for _, index := range fieldPathIndexes.FindAllStringSubmatch(match[2], -1) {
+			if index[1] == "*" {
+				result = append(result, fieldPathSegment{isIndex: true, wildcard: true})
+				continue
+			}
+			i, err := strconv.Atoi(index[1])
+			if err != nil {
+				return nil, fmt.Errorf("invalid field path %q: %w", path, err)
+			}
+			result = append(result, fieldPathSegment{isIndex: true, index: i})
+		}
+	}
+	return result, nil
+}
+
+func (p fieldPath) String() string {
+	var b strings.Builder
+	for _, segment := range p {
+		switch {
+		case segment.wildcard:
+			b.WriteString("[*]")
+		case segment.isIndex:
+			fmt.Fprintf(&b, "[%d]", segment.index)
+		default:
+			b.WriteString("." + segment.field)
+		}
+	}
+	return b.String()
+}
+
+// expand resolves the wildcards of the path against an object and returns the matching concrete paths.
+// Missing fields are kept so they can be created, paths through missing array elements are dropped.
+func (p fieldPath) expand(object map[string]any) []fieldPath {
+	return expandFieldPath(object, p, nil)
+}
+
+func expandFieldPath(node any, remaining fieldPath, prefix fieldPath) []fieldPath {
+	if len(remaining) == 0 {
+		return []fieldPath{slices.Clone(prefix)}
+	}
+
+	segment := remaining[0]
+	if !segment.isIndex {
+		var child any
+		if object, ok := node.(map[string]any); ok {
+			child = object[segment.field]
+		}
+		return expandFieldPath(child, remaining[1:], append(prefix, segment))
+	}
+
+	array, _ := node.([]any)
+	if !segment.wildcard {
+		if segment.index >= len(array) {
+			return nil
+		}
+		return expandFieldPath(array[segment.index], remaining[1:], append(prefix, segment))
+	}
+
+	var result []fieldPath
+	for i, element := range array {
+		concrete := fieldPathSegment{isIndex: true, index: i}
+		result = append(result, expandFieldPath(element, remaining[1:], append(prefix, concrete))...)
+	}
+	return result
+}
+
+// get returns the value at a concrete path and whether it is present.
+func (p fieldPath) get(object map[string]any) (any, bool) {
+	var node any = object
+	for _, segment := range p {
+		if segment.isIndex {
+			array, ok := node.([]any)
+			if !ok || segment.index >= len(array) {
+				return nil, false
+			}
+			node = array[segment.index]
+			continue
+		}
+
+		fields, ok := node.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		node, ok = fields[segment.field]
+		if !ok {
+			return nil, false
+		}
+	}
+	return node, true
+}
+
+// set writes the value at a concrete path, creating missing intermediate objects.
+func (p fieldPath) set(object map[string]any, value any) error {
+	var node any = object
+	for i, segment := range p {
+		last := i == len(p)-1
+
+		if segment.isIndex {
+			array, ok := node.([]any)
+			if !ok || segment.index >= len(array) {
+				return fmt.Errorf("field path %s: no array element at index %d", p, segment.index)
+			}
+			if last {
+				array[segment.index] = value
+				return nil
+			}
+			node = array[segment.index]
+			continue
+		}
+
+		fields, ok := node.(map[string]any)
+		if !ok {
+			return fmt.Errorf("field path %s: parent of %s is not an object", p, segment.field)
+		}
+		if last {
+			fields[segment.field] = value
+			return nil
+		}
+		child, ok := fields[segment.field]
+		if !ok || child == nil {
+			child = map[string]any{}
+			fields[segment.field] = child
+		}
+		node = child
+	}
+	return nil
+}
+
+// remove deletes the field at a concrete path. Array elements are never removed.
+func (p fieldPath) remove(object map[string]any) {
+	if len(p) == 0 || p[len(p)-1].isIndex {
+		return
+	}
+	parent, ok := p[:len(p)-1].get(object)
+	if !ok {
+		return
+	}
+	if fields, ok := parent.(map[string]any); ok {
+		delete(fields, p[len(p)-1].field)
+	}
+}
@@ -0,0 +1,107 @@
+package k8s
+
+func (s *Unittest) TestParseFieldPath() {
+	tests := []struct {
+		name     string
+		path     string
+		expected string
+	}{
+		{"leading dot", ".spec.suspend", ".spec.suspend"},
+		{"no leading dot", "spec.suspend", ".spec.suspend"},
+		{"braces", "{.spec.runStrategy}", ".spec.runStrategy"},
+		{"index", ".spec.nodePools[1].replicas", ".spec.nodePools[1].replicas"},
+		{"wildcard", ".spec.nodePools[*].replicas", ".spec.nodePools[*].replicas"},
+		{"nested arrays", ".spec.matrix[*][0]", ".spec.matrix[*][0]"},
+	}
+
+	for _, testCase := range tests {
+		s.Run(testCase.name, func() {
+			path, err := parseFieldPath(testCase.path)
+			s.Require().NoError(err)
+			s.Require().Equal(testCase.expected, path.String())
+		})
+	}
+}
+
+func (s *Unittest) TestParseInvalidFieldPath() {
+	for _, path := range []string{"", ".", "{}", ".spec..suspend", ".spec.pools[x]", ".spec.pools[", ".spec[0"} {
+		s.Run(path, func() {
+			_, err := parseFieldPath(path)
+			s.Require().Error(err)
+		})
+	}
+}
+
+func testFieldPathObject() map[string]any {
+	return map[string]any{
+		"spec": map[string]any{
+			"nodePools": []any{
+				map[string]any{"name": "a", "replicas": int64(3)},
+				map[string]any{"name": "b"},
+			},
+		},
+	}
+}
+
+func (s *Unittest) TestExpandFieldPath() {
+	tests := []struct {
+		name     string
+		path     string
+		expected []string
+	}{
+		{"plain field", ".spec.suspend", []string{".spec.suspend"}},
+		{"missing parent", ".spec.missing.suspend", []string{".spec.missing.suspend"}},
+		{"wildcard", ".spec.nodePools[*].replicas", []string{".spec.nodePools[0].replicas", ".spec.nodePools[1].replicas"}},
+		{"index", ".spec.nodePools[1].replicas", []string{".spec.nodePools[1].replicas"}},
+		{"index out of range", ".spec.nodePools[2].replicas", nil},
+		{"wildcard on missing array", ".spec.others[*].replicas", nil},
+	}
+
+	for _, testCase := range tests {
+		s.Run(testCase.name, func() {
+			path, err := parseFieldPath(testCase.path)
+			s.Require().NoError(err)
+
+			var actual []string
+			for _, concrete := range path.expand(testFieldPathObject()) {
+				actual = append(actual, concrete.String())
+			}
+			s.Require().Equal(testCase.expected, actual)
+		})
+	}
+}
+
+func (s *Unittest) TestFieldPathGetSetRemove() {
+	object := testFieldPathObject()
+	replicas, err := parseFieldPath(".spec.nodePools[0].replicas")
+	s.Require().NoError(err)
+	suspend, err := parseFieldPath(".spec.lifecycle.suspend")
+	s.Require().NoError(err)
+
+	value, found := replicas.get(object)
+	s.Require().True(found)
+	s.Require().Equal(int64(3), value)
+
+	s.Require().NoError(replicas.set(object, int64(0)))
+	value, _ = replicas.get(object)
+	s.Require().Equal(int64(0), value)
+
+	_, found = suspend.get(object)
+	s.Require().False(found)
+	s.Require().NoError(suspend.set(object, true))
+	value, found = suspend.get(object)
+	s.Require().True(found)
+	s.Require().Equal(true, value)
+
+	suspend.remove(object)
+	_, found = suspend.get(object)
+	s.Require().False(found)
+}
+
+func (s *Unittest) TestFieldPathSetThroughScalar() {
+	object := map[string]any{"spec": "scalar"}
+	path, err := parseFieldPath(".spec.suspend")
+	s.Require().NoError(err)
+
+	s.Require().Error(path.set(object, true))
+}
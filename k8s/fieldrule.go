@@ -0,0 +1,228 @@
+package k8s
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"reflect"
+
+	kubesleep "github.com/Y0-L0/kubesleep/kubesleep"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// fieldRuleEntry records the original value of one concrete field path.
+// A missing Value means the field did not exist and is removed again on wake.
+type fieldRuleEntry struct {
+	Path  string          `json:"path"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+func (k8s K8Simpl) getFieldRuleSuspendables(ctx context.Context, namespace string) (map[string]kubesleep.Suspendable, error) {
+	suspendables := map[string]kubesleep.Suspendable{}
+
+	for _, rule := range k8s.options.FieldRules {
+		gvk := rule.GroupVersionKind()
+		mapping, err := k8s.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+		if meta.IsNoMatchError(err) {
+			slog.Debug("Kind of field rule is not installed; skipping rule", "gvk", gvk)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		path, err := parseFieldPath(rule.Path)
+		if err != nil {
+			return nil, err
+		}
+		asleep, err := decodeJsonValue(rule.Asleep)
+		if err != nil {
+			return nil, fmt.Errorf("invalid asleep value for kind %s: %w", gvk, err)
+		}
+
+		objects, err := k8s.dynamic.Resource(mapping.Resource).
+			Namespace(namespace).
+			List(ctx, metav1.ListOptions{})
+		if apierrors.IsForbidden(err) {
+			slog.Warn("Missing permissions to list resource of field rule; skipping it", "resource", mapping.Resource, "namespace", namespace)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		for _, object := range objects.Items {
+			entries, awake, err := readFieldRuleEntries(object.Object, path, rule.Asleep)
+			if err != nil {
+				return nil, err
+			}
+			state, err := json.Marshal(entries)
+			if err != nil {
+				return nil, err
+			}
+
+			var suspend func(context.Context) error
+			var replicas int32
+			if awake {
+				suspend = k8s.suspendFieldRule(namespace, mapping.Resource, object.GetName(), path, asleep)
+				replicas = 1
+			} else {
+				suspend = k8s.noopSuspendFieldRule(namespace, gvk.Kind, object.GetName())
+			}
+
+			s := kubesleep.NewSuspendable(
+				kubesleep.RuleBased,
+				object.GetName(),
+				replicas,
+				suspend,
+			).WithResource(gvk.GroupVersion().String(), gvk.Kind).WithState(state)
+			slog.Debug("parsed Suspendable", "Suspendable", s, "namespace", namespace)
+			suspendables[s.Identifier()] = s
+		}
+	}
+
+	return suspendables, nil
+}
+
+// readFieldRuleEntries records the current value of every field matched by the path
+// and reports whether any of them differs from the asleep value.
+func readFieldRuleEntries(object map[string]any, path fieldPath, asleep json.RawMessage) ([]fieldRuleEntry, bool, error) {
+	entries := []fieldRuleEntry{}
+	awake := false
+	for _, concrete := range path.expand(object) {
+		entry := fieldRuleEntry{Path: concrete.String()}
+		if value, found := concrete.get(object); found {
+			var err error
+			entry.Value, err = json.Marshal(value)
+			if err != nil {
+				return nil, false, err
+			}
+		}
+		equal, err := jsonEqual(entry.Value, asleep)
+		if err != nil {
+			return nil, false, err
+		}
+		awake = awake || !equal
+		entries = append(entries, entry)
+	}
+	return entries, awake, nil
+}
+
+func (k8s K8Simpl) noopSuspendFieldRule(namespace, kind, name string) func(context.Context) error {
+	return func(ctx context.Context) error {
+		slog.Debug("Resource already matches its field rule; skipping suspend", "namespace", namespace, "kind", kind, "name", name)
+		return nil
+	}
+}
+
+func (k8s K8Simpl) suspendFieldRule(namespace string, resource schema.GroupVersionResource, name string, path fieldPath, asleep any) func(context.Context) error {
+	return func(ctx context.Context) error {
+		object, err := k8s.dynamic.Resource(resource).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		for _, concrete := range path.expand(object.Object) {
+			if err := concrete.set(object.Object, runtime.DeepCopyJSONValue(asleep)); err != nil {
+				return err
+			}
+		}
+
+		_, err = k8s.dynamic.Resource(resource).Namespace(namespace).Update(ctx, object, metav1.UpdateOptions{})
+		if err != nil {
+			return err
+		}
+		slog.Info("Suspended resource by field rule", "resource", resource, "name", name, "namespace", namespace)
+		return nil
+	}
+}
+
+func (k8s K8Simpl) wakeFieldRule(ctx context.Context, namespace string, suspendable kubesleep.Suspendable) error {
+	var entries []fieldRuleEntry
+	if err := json.Unmarshal(suspendable.State(), &entries); err != nil {
+		return fmt.Errorf("invalid field rule state: %w", err)
+	}
+
+	gvk := schema.FromAPIVersionAndKind(suspendable.APIVersion(), suspendable.Kind())
+	mapping, err := k8s.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return err
+	}
+	resources := k8s.dynamic.Resource(mapping.Resource).Namespace(namespace)
+
+	object, err := resources.Get(ctx, suspendable.Name(), metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if err := restoreFieldRuleEntries(object, entries); err != nil {
+		return err
+	}
+
+	_, err = resources.Update(ctx, object, metav1.UpdateOptions{})
+	if err != nil {
+		return err
+	}
+	slog.Info("Woke up resource by field rule", "resource", mapping.Resource, "name", suspendable.Name(), "namespace", namespace)
+	return nil
+}
+
+func restoreFieldRuleEntries(object *unstructured.Unstructured, entries []fieldRuleEntry) error {
+	for _, entry := range entries {
+		path, err := parseFieldPath(entry.Path)
+		if err != nil {
+			return err
+		}
+		if len(entry.Value) == 0 {
+			path.remove(object.Object)
+			continue
+		}
+		value, err := decodeJsonValue(entry.Value)
+		if err != nil {
+			return err
+		}
+		if err := path.set(object.Object, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// decodeJsonValue decodes a JSON document into the value types used by unstructured objects.
+func decodeJsonValue(data json.RawMessage) (any, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value any
+	err := decoder.Decode(&value)
+	return value, err
+}
+
+// jsonEqual compares two JSON documents semantically. An empty document only equals another empty document.
+func jsonEqual(a, b json.RawMessage) (bool, error) {
+	if len(a) == 0 || len(b) == 0 {
+		return len(a) == len(b), nil
+	}
+	var valueA, valueB any
+	if err := json.Unmarshal(a, &valueA); err != nil {
+		return false, err
+	}
+	if err := json.Unmarshal(b, &valueB); err != nil {
+		return false, err
+	}
+	return reflect.DeepEqual(valueA, valueB), nil
+}
+
+func (k8s K8Simpl) hasFieldRule(groupKind schema.GroupKind) bool {
+	for _, rule := range k8s.options.FieldRules {
+		if rule.GroupVersionKind().GroupKind() == groupKind {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,123 @@
+package k8s
+
+import (
+	"encoding/json"
+
+	kubesleep "github.com/Y0-L0/kubesleep/kubesleep"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func gadgetRules() []kubesleep.FieldRule {
+	return []kubesleep.FieldRule{
+		{
+			APIVersion: "test.kubesleep.xyz/v1",
+			Kind:       "Gadget",
+			Path:       ".spec.nodePools[*].replicas",
+			Asleep:     json.RawMessage("0"),
+		},
+	}
+}
+
+func (s *Unittest) TestReadAndRestoreFieldRuleEntries() {
+	object := testFieldPathObject()
+	path, err := parseFieldPath(".spec.nodePools[*].replicas")
+	s.Require().NoError(err)
+
+	entries, awake, err := readFieldRuleEntries(object, path, json.RawMessage("0"))
+	s.Require().NoError(err)
+	s.Require().True(awake)
+	s.Require().Equal(
+		[]fieldRuleEntry{
+			{Path: ".spec.nodePools[0].replicas", Value: json.RawMessage("3")},
+			{Path: ".spec.nodePools[1].replicas"},
+		},
+		entries,
+	)
+
+	for _, concrete := range path.expand(object) {
+		s.Require().NoError(concrete.set(object, int64(0)))
+	}
+	_, awake, err = readFieldRuleEntries(object, path, json.RawMessage("0"))
+	s.Require().NoError(err)
+	s.Require().False(awake)
+
+	restored := &unstructured.Unstructured{Object: object}
+	s.Require().NoError(restoreFieldRuleEntries(restored, entries))
+	expected, err := json.Marshal(testFieldPathObject())
+	s.Require().NoError(err)
+	actual, err := json.Marshal(restored.Object)
+	s.Require().NoError(err)
+	s.Require().JSONEq(string(expected), string(actual))
+}
+
+func (s *Unittest) TestJsonEqual() {
+	tests := []struct {
+		name     string
+		a, b     string
+		expected bool
+	}{
+		{"equal numbers", "0", "0", true},
+		{"integer and float", "1", "1.0", true},
+		{"different strings", `"Halted"`, `"Always"`, false},
+		{"objects ignore whitespace", `{"a": 1}`, `{"a":1}`, true},
+		{"missing value", "", "0", false},
+		{"both missing", "", "", true},
+	}
+
+	for _, testCase := range tests {
+		s.Run(testCase.name, func() {
+			equal, err := jsonEqual(json.RawMessage(testCase.a), json.RawMessage(testCase.b))
+			s.Require().NoError(err)
+			s.Require().Equal(testCase.expected, equal)
+		})
+	}
+}
+
+func (s *Integrationtest) TestFieldRule_SuspendAndWake() {
+	deleteNamespace, err := testNamespace(s.ctx, "field-rules", s.k8s, false)
+	s.Require().NoError(err)
+	defer deleteNamespace()
+
+	delete, err := CreateCustomResource(s.ctx, *s.k8s, GADGET_GVR, "Gadget", "field-rules", "test-gadget", map[string]any{
+		"nodePools": []any{
+			map[string]any{"name": "a", "replicas": int64(3)},
+			map[string]any{"name": "b", "replicas": int64(0)},
+		},
+	})
+	s.Require().NoError(err)
+	defer delete()
+
+	k8s := *s.k8s
+	k8s.options = kubesleep.K8SOptions{FieldRules: gadgetRules()}
+	suspendables, err := k8s.GetSuspendables(s.ctx, "field-rules")
+	s.Require().NoError(err)
+	before, ok := suspendables["4:Gadget.test.kubesleep.xyz:test-gadget"]
+	s.Require().True(ok)
+	s.Require().Equal(int32(1), before.Replicas)
+
+	s.Require().NoError(before.Suspend(s.ctx))
+
+	gadget, err := k8s.dynamic.Resource(GADGET_GVR).Namespace("field-rules").Get(s.ctx, "test-gadget", metav1.GetOptions{})
+	s.Require().NoError(err)
+	pools, _, _ := unstructured.NestedSlice(gadget.Object, "spec", "nodePools")
+	s.Require().Equal(int64(0), pools[0].(map[string]any)["replicas"])
+
+	s.Require().NoError(k8s.ScaleSuspendable(s.ctx, "field-rules", before))
+
+	gadget, err = k8s.dynamic.Resource(GADGET_GVR).Namespace("field-rules").Get(s.ctx, "test-gadget", metav1.GetOptions{})
+	s.Require().NoError(err)
+	pools, _, _ = unstructured.NestedSlice(gadget.Object, "spec", "nodePools")
+	s.Require().Equal(int64(3), pools[0].(map[string]any)["replicas"])
+	s.Require().Equal(int64(0), pools[1].(map[string]any)["replicas"])
+}
+
+func (s *Integrationtest) TestFieldRule_UninstalledKindIsSkipped() {
+	k8s := *s.k8s
+	k8s.options = kubesleep.K8SOptions{FieldRules: []kubesleep.FieldRule{
+		{APIVersion: "missing.kubesleep.xyz/v1", Kind: "Missing", Path: ".spec.suspend", Asleep: json.RawMessage("true")},
+	}}
+
+	_, err := k8s.getFieldRuleSuspendables(s.ctx, "default")
+	s.Require().NoError(err)
+}
@@ -0,0 +1,161 @@
+package k8s
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	kubesleep "github.com/Y0-L0/kubesleep/kubesleep"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+)
+
+var (
+	FLUX_KUSTOMIZATION = schema.GroupKind{Group: "kustomize.toolkit.fluxcd.io", Kind: "Kustomization"}
+	FLUX_HELM_RELEASE  = schema.GroupKind{Group: "helm.toolkit.fluxcd.io", Kind: "HelmRelease"}
+)
+
+// getFluxResources returns the Flux Kustomizations and HelmReleases in any namespace that deploy into the given namespace.
+// Objects that are already suspended are recorded as well, so wake knows to leave them suspended.
+func (k8s K8Simpl) getFluxResources(ctx context.Context, namespace string) (map[string]kubesleep.Suspendable, error) {
+	suspendables := map[string]kubesleep.Suspendable{}
+
+	for _, groupKind := range []schema.GroupKind{FLUX_KUSTOMIZATION, FLUX_HELM_RELEASE} {
+		mapping, err := k8s.mapper.RESTMapping(groupKind)
+		if meta.IsNoMatchError(err) {
+			slog.Debug("Flux kind is not installed; skipping it", "kind", groupKind, "namespace", namespace)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		objects, err := k8s.dynamic.Resource(mapping.Resource).List(ctx, metav1.ListOptions{})
+		if apierrors.IsForbidden(err) {
+			slog.Warn("Missing permissions to list Flux resources; Flux may revert the suspension", "resource", mapping.Resource, "namespace", namespace)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		for _, object := range objects.Items {
+			targets, shared := fluxTargetsNamespace(object, namespace)
+			if shared {
+				slog.Warn("Flux Kustomization deploys into other namespaces as well; not suspending it, Flux may revert the suspension", "name", object.GetName(), "kustomizationNamespace", object.GetNamespace(), "namespace", namespace)
+			}
+			if !targets {
+				continue
+			}
+
+			suspended, _, err := unstructured.NestedBool(object.Object, "spec", "suspend")
+			if err != nil {
+				return nil, err
+			}
+			key, err := cache.MetaNamespaceKeyFunc(&object)
+			if err != nil {
+				return nil, err
+			}
+
+			var suspend func(context.Context) error
+			if suspended {
+				suspend = k8s.noopSuspendFluxResource(groupKind.Kind, key)
+			} else {
+				suspend = k8s.suspendFluxResource(mapping.Resource, object.GetNamespace(), object.GetName())
+			}
+
+			s := kubesleep.NewSuspendable(
+				kubesleep.FluxResource,
+				key,
+				0,
+				suspend,
+			).WithResource(mapping.GroupVersionKind.GroupVersion().String(), groupKind.Kind).WithSuspended(suspended)
+			slog.Debug("parsed Suspendable", "Suspendable", s, "namespace", namespace)
+			suspendables[s.Identifier()] = s
+		}
+	}
+
+	return suspendables, nil
+}
+
+// fluxTargetsNamespace reports whether a Kustomization or HelmRelease deploys only into the namespace.
+// Objects without a spec.targetNamespace are matched by their inventory, which must not contain objects
+// of other namespaces or cluster scoped objects besides the namespace itself. shared reports a Kustomization
+// that deploys into the namespace and elsewhere: suspending it would halt the other namespaces as well.
+// HelmReleases fall back to their own namespace like helm-controller does.
+func fluxTargetsNamespace(object unstructured.Unstructured, namespace string) (targets bool, shared bool) {
+	if target, found, _ := unstructured.NestedString(object.Object, "spec", "targetNamespace"); found && target != "" {
+		return target == namespace, false
+	}
+	if object.GetKind() == FLUX_HELM_RELEASE.Kind {
+		return object.GetNamespace() == namespace, false
+	}
+
+	entries, _, _ := unstructured.NestedSlice(object.Object, "status", "inventory", "entries")
+	inside, outside := false, false
+	for _, entry := range entries {
+		// Inventory ids have the form <namespace>_<name>_<group>_<kind>, cluster scoped objects have no namespace.
+		id, _, _ := unstructured.NestedString(entry.(map[string]any), "id")
+		switch {
+		case strings.HasPrefix(id, namespace+"_"):
+			inside = true
+		case id == "_"+namespace+"__Namespace":
+		default:
+			outside = true
+		}
+	}
+	return inside && !outside, inside && outside
+}
+
+func (k8s K8Simpl) noopSuspendFluxResource(kind, key string) func(context.Context) error {
+	return func(ctx context.Context) error {
+		slog.Debug("Flux resource already suspended; skipping suspend", "kind", kind, "name", key)
+		return nil
+	}
+}
+
+func (k8s K8Simpl) suspendFluxResource(resource schema.GroupVersionResource, namespace, name string) func(context.Context) error {
+	return func(ctx context.Context) error {
+		patch := []byte(`{"spec":{"suspend":true}}`)
+		_, err := k8s.dynamic.Resource(resource).Namespace(namespace).Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{})
+		if err != nil {
+			return err
+		}
+		slog.Info("Suspended Flux resource", "resource", resource, "name", name, "namespace", namespace)
+		return nil
+	}
+}
+
+func (k8s K8Simpl) wakeFluxResource(ctx context.Context, suspendable kubesleep.Suspendable) error {
+	if suspendable.Suspended() {
+		slog.Info("Flux resource was suspended before kubesleep; leaving it suspended", "kind", suspendable.Kind(), "name", suspendable.Name())
+		return nil
+	}
+
+	namespace, name, err := cache.SplitMetaNamespaceKey(suspendable.Name())
+	if err != nil {
+		return err
+	}
+	gvk := schema.FromAPIVersionAndKind(suspendable.APIVersion(), suspendable.Kind())
+	mapping, err := k8s.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return err
+	}
+
+	patch := []byte(`{"spec":{"suspend":null}}`)
+	_, err = k8s.dynamic.Resource(mapping.Resource).Namespace(namespace).Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{})
+	if apierrors.IsNotFound(err) {
+		slog.Warn("Flux resource was deleted while the namespace was suspended; skipping wake", "resource", mapping.Resource, "name", name, "namespace", namespace)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	slog.Info("Resumed Flux resource", "resource", mapping.Resource, "name", name, "namespace", namespace)
+	return nil
+}
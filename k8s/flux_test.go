@@ -0,0 +1,156 @@
+package k8s
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func (s *Integrationtest) isFluxSuspended(gvr schema.GroupVersionResource, namespace, name string) bool {
+	object, err := s.k8s.dynamic.Resource(gvr).Namespace(namespace).Get(s.ctx, name, metav1.GetOptions{})
+	s.Require().NoError(err)
+	suspended, _, err := unstructured.NestedBool(object.Object, "spec", "suspend")
+	s.Require().NoError(err)
+	return suspended
+}
+
+func (s *Integrationtest) TestFluxResource_Get() {
+	deleteNamespace, err := testNamespace(s.ctx, "get-flux", s.k8s, false)
+	s.Require().NoError(err)
+	defer deleteNamespace()
+	deleteFluxNamespace, err := testNamespace(s.ctx, "get-flux-system", s.k8s, false)
+	s.Require().NoError(err)
+	defer deleteFluxNamespace()
+
+	deleteKustomization, err := CreateCustomResource(s.ctx, *s.k8s, FLUX_KUSTOMIZATION_GVR, "Kustomization", "get-flux-system", "apps", map[string]any{"targetNamespace": "get-flux"})
+	s.Require().NoError(err)
+	defer deleteKustomization()
+	deleteOther, err := CreateCustomResource(s.ctx, *s.k8s, FLUX_KUSTOMIZATION_GVR, "Kustomization", "get-flux-system", "other", map[string]any{"targetNamespace": "somewhere-else"})
+	s.Require().NoError(err)
+	defer deleteOther()
+	deleteHelmRelease, err := CreateCustomResource(s.ctx, *s.k8s, FLUX_HELM_RELEASE_GVR, "HelmRelease", "get-flux", "chart", map[string]any{})
+	s.Require().NoError(err)
+	defer deleteHelmRelease()
+
+	suspendables, err := s.k8s.GetSuspendables(s.ctx, "get-flux")
+	s.Require().NoError(err)
+	s.Require().Contains(suspendables, "13:Kustomization.kustomize.toolkit.fluxcd.io:get-flux-system/apps")
+	s.Require().Contains(suspendables, "13:HelmRelease.helm.toolkit.fluxcd.io:get-flux/chart")
+	s.Require().NotContains(suspendables, "13:Kustomization.kustomize.toolkit.fluxcd.io:get-flux-system/other")
+}
+
+// createInventoryKustomization creates a Kustomization without targetNamespace whose inventory lists the given ids.
+func (s *Integrationtest) createInventoryKustomization(namespace, name string, ids ...string) func() error {
+	delete, err := CreateCustomResource(s.ctx, *s.k8s, FLUX_KUSTOMIZATION_GVR, "Kustomization", namespace, name, map[string]any{})
+	s.Require().NoError(err)
+
+	object, err := s.k8s.dynamic.Resource(FLUX_KUSTOMIZATION_GVR).Namespace(namespace).Get(s.ctx, name, metav1.GetOptions{})
+	s.Require().NoError(err)
+	var entries []any
+	for _, id := range ids {
+		entries = append(entries, map[string]any{"id": id, "v": "v1"})
+	}
+	s.Require().NoError(unstructured.SetNestedSlice(object.Object, entries, "status", "inventory", "entries"))
+	_, err = s.k8s.dynamic.Resource(FLUX_KUSTOMIZATION_GVR).Namespace(namespace).Update(s.ctx, object, metav1.UpdateOptions{})
+	s.Require().NoError(err)
+	return delete
+}
+
+func (s *Integrationtest) TestFluxResource_SkipsSharedKustomization() {
+	for _, namespace := range []string{"shared-flux-a", "shared-flux-b", "shared-flux-system"} {
+		deleteNamespace, err := testNamespace(s.ctx, namespace, s.k8s, false)
+		s.Require().NoError(err)
+		defer deleteNamespace()
+	}
+	defer s.createInventoryKustomization("shared-flux-system", "root", "shared-flux-a_web_apps_Deployment", "shared-flux-b_web_apps_Deployment")()
+	defer s.createInventoryKustomization("shared-flux-system", "a", "_shared-flux-a__Namespace", "shared-flux-a_web_apps_Deployment")()
+
+	suspendables, err := s.k8s.GetSuspendables(s.ctx, "shared-flux-a")
+	s.Require().NoError(err)
+	s.Require().Contains(suspendables, "13:Kustomization.kustomize.toolkit.fluxcd.io:shared-flux-system/a")
+	s.Require().NotContains(suspendables, "13:Kustomization.kustomize.toolkit.fluxcd.io:shared-flux-system/root")
+
+	suspendables, err = s.k8s.GetSuspendables(s.ctx, "shared-flux-b")
+	s.Require().NoError(err)
+	s.Require().NotContains(suspendables, "13:Kustomization.kustomize.toolkit.fluxcd.io:shared-flux-system/root")
+}
+
+func (s *Integrationtest) TestFluxResource_SuspendAndWake() {
+	deleteNamespace, err := testNamespace(s.ctx, "suspend-flux", s.k8s, false)
+	s.Require().NoError(err)
+	defer deleteNamespace()
+
+	deleteKustomization, err := CreateCustomResource(s.ctx, *s.k8s, FLUX_KUSTOMIZATION_GVR, "Kustomization", "suspend-flux", "apps", map[string]any{"targetNamespace": "suspend-flux"})
+	s.Require().NoError(err)
+	defer deleteKustomization()
+
+	before := s.getSuspendable("suspend-flux", "13:Kustomization.kustomize.toolkit.fluxcd.io:suspend-flux/apps")
+	s.Require().False(before.Suspended())
+	s.Require().NoError(before.Suspend(s.ctx))
+	s.Require().True(s.isFluxSuspended(FLUX_KUSTOMIZATION_GVR, "suspend-flux", "apps"))
+
+	s.Require().NoError(s.k8s.ScaleSuspendable(s.ctx, "suspend-flux", before))
+	s.Require().False(s.isFluxSuspended(FLUX_KUSTOMIZATION_GVR, "suspend-flux", "apps"))
+}
+
+func (s *Integrationtest) TestFluxResource_KeepsManualSuspension() {
+	deleteNamespace, err := testNamespace(s.ctx, "manual-flux", s.k8s, false)
+	s.Require().NoError(err)
+	defer deleteNamespace()
+
+	deleteHelmRelease, err := CreateCustomResource(s.ctx, *s.k8s, FLUX_HELM_RELEASE_GVR, "HelmRelease", "manual-flux", "chart", map[string]any{"suspend": true})
+	s.Require().NoError(err)
+	defer deleteHelmRelease()
+
+	before := s.getSuspendable("manual-flux", "13:HelmRelease.helm.toolkit.fluxcd.io:manual-flux/chart")
+	s.Require().True(before.Suspended())
+	s.Require().NoError(before.Suspend(s.ctx))
+
+	s.Require().NoError(s.k8s.ScaleSuspendable(s.ctx, "manual-flux", before))
+	s.Require().True(s.isFluxSuspended(FLUX_HELM_RELEASE_GVR, "manual-flux", "chart"))
+}
+
+func (s *Unittest) TestFluxTargetsNamespace() {
+	kustomization := func(namespace string, spec map[string]any, entries ...any) unstructured.Unstructured {
+		object := unstructured.Unstructured{Object: map[string]any{"spec": spec}}
+		object.SetKind("Kustomization")
+		object.SetNamespace(namespace)
+		if len(entries) > 0 {
+			object.Object["status"] = map[string]any{"inventory": map[string]any{"entries": entries}}
+		}
+		return object
+	}
+	helmRelease := func(namespace string, spec map[string]any) unstructured.Unstructured {
+		object := unstructured.Unstructured{Object: map[string]any{"spec": spec}}
+		object.SetKind("HelmRelease")
+		object.SetNamespace(namespace)
+		return object
+	}
+
+	tests := []struct {
+		name    string
+		object  unstructured.Unstructured
+		targets bool
+		shared  bool
+	}{
+		{"target namespace", kustomization("flux-system", map[string]any{"targetNamespace": "dev"}), true, false},
+		{"other target namespace", kustomization("dev", map[string]any{"targetNamespace": "prod"}), false, false},
+		{"inventory", kustomization("flux-system", map[string]any{}, map[string]any{"id": "dev_web_apps_Deployment"}), true, false},
+		{"inventory with the namespace", kustomization("flux-system", map[string]any{}, map[string]any{"id": "_dev__Namespace"}, map[string]any{"id": "dev_web_apps_Deployment"}), true, false},
+		{"inventory of another namespace", kustomization("flux-system", map[string]any{}, map[string]any{"id": "development_web_apps_Deployment"}), false, false},
+		{"inventory of two namespaces", kustomization("flux-system", map[string]any{}, map[string]any{"id": "dev_web_apps_Deployment"}, map[string]any{"id": "prod_web_apps_Deployment"}), false, true},
+		{"inventory with cluster scoped objects", kustomization("flux-system", map[string]any{}, map[string]any{"id": "dev_web_apps_Deployment"}, map[string]any{"id": "_web_rbac.authorization.k8s.io_ClusterRole"}), false, true},
+		{"empty inventory", kustomization("dev", map[string]any{}), false, false},
+		{"helm release", helmRelease("dev", map[string]any{}), true, false},
+		{"helm release with target namespace", helmRelease("flux-system", map[string]any{"targetNamespace": "dev"}), true, false},
+		{"helm release of another namespace", helmRelease("flux-system", map[string]any{}), false, false},
+	}
+
+	for _, testCase := range tests {
+		s.Run(testCase.name, func() {
+			targets, shared := fluxTargetsNamespace(testCase.object, "dev")
+			s.Require().Equal(testCase.targets, targets)
+			s.Require().Equal(testCase.shared, shared)
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package k8s
+
+import (
+	"context"
+	"log/slog"
+
+	kubesleep "github.com/Y0-L0/kubesleep/kubesleep"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+)
+
+var CRONJOB_GROUP_KIND = schema.GroupKind{Group: "batch", Kind: "CronJob"}
+
+func (k8s K8Simpl) getJobs(ctx context.Context, namespace string) (map[string]kubesleep.Suspendable, error) {
+	jobs, err := k8s.clientset.BatchV1().
+		Jobs(namespace).
+		List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	suspendables := map[string]kubesleep.Suspendable{}
+
+	for _, job := range jobs.Items {
+		if jobFinished(job) {
+			slog.Debug("Skipping finished Job", "namespace", namespace, "name", job.Name)
+			continue
+		}
+		if owner := metav1.GetControllerOf(&job); owner != nil {
+			// Every CronJob in the namespace is suspended as well.
+			if schema.FromAPIVersionAndKind(owner.APIVersion, owner.Kind).GroupKind() == CRONJOB_GROUP_KIND {
+				slog.Debug("Skipping Job owned by a CronJob", "namespace", namespace, "name", job.Name, "cronJob", owner.Name)
+				continue
+			}
+			s := k8s.operatorManagedSuspendable(namespace, "batch/v1", "Job", job.Name, *owner)
+			suspendables[s.Identifier()] = s
+			continue
+		}
+
+		suspended := job.Spec.Suspend != nil && *job.Spec.Suspend
+		var suspend func(context.Context) error
+		if suspended {
+			suspend = k8s.noopSuspendJob(namespace, job.Name)
+		} else {
+			suspend = k8s.suspendJob(namespace, job.Name)
+		}
+
+		var replicas int32
+		if !suspended {
+			replicas = ptr.Deref(job.Spec.Parallelism, 1)
+		}
+
+		s := kubesleep.NewSuspendable(
+			kubesleep.Job,
+			job.Name,
+			replicas,
+			suspend,
+		).WithSuspended(suspended)
+		slog.Debug("parsed Suspendable", "Suspendable", s, "namespace", namespace)
+		suspendables[s.Identifier()] = s
+	}
+
+	return suspendables, nil
+}
+
+func jobFinished(job batchv1.Job) bool {
+	for _, condition := range job.Status.Conditions {
+		if (condition.Type == batchv1.JobComplete || condition.Type == batchv1.JobFailed) && condition.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
+
+func (k8s K8Simpl) noopSuspendJob(namespace, name string) func(context.Context) error {
+	return func(ctx context.Context) error {
+		slog.Debug("Job already suspended; skipping suspend", "namespace", namespace, "name", name)
+		return nil
+	}
+}
+
+func (k8s K8Simpl) suspendJob(namespace, name string) func(context.Context) error {
+	return func(ctx context.Context) error {
+		result := true
+		return k8s.setJobSuspended(ctx, namespace, name, &result)
+	}
+}
+
+func (k8s K8Simpl) scaleJob(ctx context.Context, namespace, name string, suspended bool) error {
+	err := k8s.setJobSuspended(ctx, namespace, name, &suspended)
+	if apierrors.IsNotFound(err) {
+		slog.Warn("Job was deleted while the namespace was suspended; skipping wake", "name", name, "namespace", namespace)
+		return nil
+	}
+	return err
+}
+
+func (k8s K8Simpl) setJobSuspended(ctx context.Context, namespace, name string, suspended *bool) error {
+	job, err := k8s.clientset.BatchV1().Jobs(namespace).Get(
+		ctx,
+		name,
+		metav1.GetOptions{},
+	)
+	if err != nil {
+		return err
+	}
+
+	job.Spec.Suspend = suspended
+
+	_, err = k8s.clientset.BatchV1().Jobs(namespace).Update(
+		ctx,
+		job,
+		metav1.UpdateOptions{},
+	)
+	if err != nil {
+		return err
+	}
+	if *suspended {
+		slog.Info("Suspended Job", "name", name, "namespace", namespace)
+	} else {
+		slog.Info("Woke up Job", "name", name, "namespace", namespace)
+	}
+	return nil
+}
@@ -0,0 +1,169 @@
+package k8s
+
+import (
+	"context"
+
+	kubesleep "github.com/Y0-L0/kubesleep/kubesleep"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func CreateJob(ctx context.Context, k8s K8Simpl, namespace string, name string, suspended bool, owners ...metav1.OwnerReference) (func() error, error) {
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			Namespace:       namespace,
+			OwnerReferences: owners,
+		},
+		Spec: batchv1.JobSpec{
+			Suspend: &suspended,
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					RestartPolicy: corev1.RestartPolicyNever,
+					Containers: []corev1.Container{
+						{
+							Name:  name,
+							Image: "k8s.gcr.io/pause:3.9",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	_, err := k8s.clientset.BatchV1().Jobs(namespace).Create(ctx, job, metav1.CreateOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	delete := func() error {
+		return k8s.clientset.BatchV1().Jobs(namespace).Delete(ctx, name, metav1.DeleteOptions{
+			PropagationPolicy: ptr.To(metav1.DeletePropagationBackground),
+		})
+	}
+	return delete, nil
+}
+
+func (s *Integrationtest) TestJob_Get() {
+	deleteNamespace, err := testNamespace(s.ctx, "get-jobs", s.k8s, false)
+	s.Require().NoError(err)
+	defer deleteNamespace()
+
+	delete, err := CreateJob(s.ctx, *s.k8s, "get-jobs", "test-job", false)
+	s.Require().NoError(err)
+	defer delete()
+
+	actual := s.getSuspendable("get-jobs", "6:test-job")
+	actual.Suspend = nil
+	s.Require().Equal(
+		kubesleep.NewSuspendable(
+			kubesleep.Job,
+			"test-job",
+			int32(1),
+			nil,
+		).WithSuspended(false),
+		actual,
+	)
+}
+
+func (s *Integrationtest) TestJob_SuspendAndScale() {
+	deleteNamespace, err := testNamespace(s.ctx, "suspend-jobs", s.k8s, false)
+	s.Require().NoError(err)
+	defer deleteNamespace()
+
+	delete, err := CreateJob(s.ctx, *s.k8s, "suspend-jobs", "test-job", false)
+	s.Require().NoError(err)
+	defer delete()
+
+	before := s.getSuspendable("suspend-jobs", "6:test-job")
+	s.Require().NoError(before.Suspend(s.ctx))
+
+	suspended := s.getSuspendable("suspend-jobs", "6:test-job")
+	s.Require().Equal(int32(0), suspended.Replicas)
+	s.Require().True(suspended.Suspended())
+
+	err = s.k8s.ScaleSuspendable(s.ctx, "suspend-jobs", before)
+	s.Require().NoError(err)
+
+	actual := s.getSuspendable("suspend-jobs", "6:test-job")
+	s.Require().Equal(int32(1), actual.Replicas)
+	s.Require().False(actual.Suspended())
+}
+
+func (s *Integrationtest) TestJob_SkipOwnedByCronJob() {
+	deleteNamespace, err := testNamespace(s.ctx, "skip-cronjob-jobs", s.k8s, false)
+	s.Require().NoError(err)
+	defer deleteNamespace()
+
+	owner := metav1.OwnerReference{
+		APIVersion: "batch/v1",
+		Kind:       "CronJob",
+		Name:       "test-cronjob",
+		UID:        "00000000-0000-0000-0000-000000000000",
+		Controller: ptr.To(true),
+	}
+	delete, err := CreateJob(s.ctx, *s.k8s, "skip-cronjob-jobs", "test-job", false, owner)
+	s.Require().NoError(err)
+	defer delete()
+
+	suspendables, err := s.k8s.GetSuspendables(s.ctx, "skip-cronjob-jobs")
+	s.Require().NoError(err)
+	s.Require().NotContains(suspendables, "6:test-job")
+}
+
+func (s *Integrationtest) TestJob_OperatorManaged() {
+	deleteNamespace, err := testNamespace(s.ctx, "operator-managed-jobs", s.k8s, false)
+	s.Require().NoError(err)
+	defer deleteNamespace()
+
+	for name, owner := range map[string]metav1.OwnerReference{
+		"scaledjob-job": {APIVersion: "keda.sh/v1alpha1", Kind: "ScaledJob", Name: "test-scaledjob"},
+		"cronjob-job":   {APIVersion: "example.com/v1", Kind: "CronJob", Name: "test-cronjob"},
+	} {
+		owner.UID = "00000000-0000-0000-0000-000000000001"
+		owner.Controller = ptr.To(true)
+		delete, err := CreateJob(s.ctx, *s.k8s, "operator-managed-jobs", name, false, owner)
+		s.Require().NoError(err)
+		defer delete()
+	}
+
+	suspendables, err := s.k8s.GetSuspendables(s.ctx, "operator-managed-jobs")
+	s.Require().NoError(err)
+	s.Require().NotContains(suspendables, "6:scaledjob-job")
+	s.Require().NotContains(suspendables, "6:cronjob-job")
+
+	scaledJob := suspendables["20:Job.batch:scaledjob-job"].Owner()
+	s.Require().NotNil(scaledJob)
+	s.Require().True(scaledJob.Suspended)
+	cronJob := suspendables["20:Job.batch:cronjob-job"].Owner()
+	s.Require().NotNil(cronJob)
+	s.Require().False(cronJob.Suspended)
+}
+
+func (s *Integrationtest) TestJob_WakeDeletedJob() {
+	err := s.k8s.ScaleSuspendable(s.ctx, "default", kubesleep.NewSuspendable(kubesleep.Job, "deleted-job", int32(1), nil))
+	s.Require().NoError(err)
+}
+
+func (s *Unittest) TestJobFinished() {
+	tests := []struct {
+		name       string
+		conditions []batchv1.JobCondition
+		expected   bool
+	}{
+		{"no conditions", nil, false},
+		{"complete", []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}}, true},
+		{"failed", []batchv1.JobCondition{{Type: batchv1.JobFailed, Status: corev1.ConditionTrue}}, true},
+		{"suspended", []batchv1.JobCondition{{Type: batchv1.JobSuspended, Status: corev1.ConditionTrue}}, false},
+		{"not complete", []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionFalse}}, false},
+	}
+
+	for _, testCase := range tests {
+		s.Run(testCase.name, func() {
+			job := batchv1.Job{Status: batchv1.JobStatus{Conditions: testCase.conditions}}
+			s.Require().Equal(testCase.expected, jobFinished(job))
+		})
+	}
+}
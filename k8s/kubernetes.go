@@ -2,15 +2,27 @@ package k8s
 
 import (
 	kubesleep "github.com/Y0-L0/kubesleep/kubesleep"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
+	"k8s.io/client-go/scale"
 	"k8s.io/client-go/tools/clientcmd"
 )
 
 type K8Simpl struct {
 	clientset *kubernetes.Clientset
+	dynamic   dynamic.Interface
+	discovery discovery.CachedDiscoveryInterface
+	mapper    meta.RESTMapper
+	scales    scale.ScalesGetter
+	options   kubesleep.K8SOptions
 }
 
-func NewK8S() (kubesleep.K8S, error) {
+func NewK8S(options kubesleep.K8SOptions) (kubesleep.K8S, error) {
 	kubeConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
 		clientcmd.NewDefaultClientConfigLoadingRules(),
 		&clientcmd.ConfigOverrides{},
@@ -23,11 +35,33 @@ func NewK8S() (kubesleep.K8S, error) {
 	clientConfig.QPS = 10
 	clientConfig.Burst = 100
 
-	k8s := &K8Simpl{}
+	return newK8S(clientConfig, options)
+}
+
+func newK8S(clientConfig *rest.Config, options kubesleep.K8SOptions) (*K8Simpl, error) {
+	var err error
+	k8s := &K8Simpl{options: options}
+
 	k8s.clientset, err = kubernetes.NewForConfig(clientConfig)
 	if err != nil {
 		return nil, err
 	}
+	k8s.dynamic, err = dynamic.NewForConfig(clientConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	k8s.discovery = memory.NewMemCacheClient(k8s.clientset.Discovery())
+	k8s.mapper = restmapper.NewDeferredDiscoveryRESTMapper(k8s.discovery)
+	k8s.scales, err = scale.NewForConfig(
+		clientConfig,
+		k8s.mapper,
+		dynamic.LegacyAPIPathResolverFunc,
+		scale.NewDiscoveryScaleKindResolver(k8s.discovery),
+	)
+	if err != nil {
+		return nil, err
+	}
 
 	return k8s, nil
 }
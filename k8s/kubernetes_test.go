@@ -4,6 +4,7 @@ import (
 	"os"
 	"path/filepath"
 
+	kubesleep "github.com/Y0-L0/kubesleep/kubesleep"
 	"k8s.io/client-go/tools/clientcmd"
 	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 )
@@ -32,7 +33,7 @@ func (s *Integrationtest) TestLoadKubeconfig() {
 	err = os.Setenv("KUBECONFIG", kubeconfigPath)
 	s.Require().NoError(err)
 
-	_, err = NewK8S()
+	_, err = NewK8S(kubesleep.K8SOptions{})
 	s.Require().NoError(err)
 }
 
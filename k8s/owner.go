@@ -0,0 +1,68 @@
+package k8s
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+
+	kubesleep "github.com/Y0-L0/kubesleep/kubesleep"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// operatorManagedSuspendable returns the placeholder for a workload controlled by a custom resource.
+// Scaling it would be reverted by the operator, so it is never touched. If kubesleep suspends the owner,
+// the workload goes down with it, otherwise the status output warns that it keeps running.
+func (k8s K8Simpl) operatorManagedSuspendable(namespace, apiVersion, kind, name string, owner metav1.OwnerReference) kubesleep.Suspendable {
+	suspended := k8s.suspendsOwner(owner)
+	if suspended {
+		slog.Debug("Skipping workload managed by an operator; its owner is suspended instead", "kind", kind, "name", name, "owner", owner.Name, "ownerKind", owner.Kind, "namespace", namespace)
+	} else {
+		slog.Warn("Workload is managed by an operator kubesleep can't suspend; it keeps running", "kind", kind, "name", name, "owner", owner.Name, "ownerKind", owner.Kind, "namespace", namespace)
+	}
+
+	s := kubesleep.NewSuspendable(
+		kubesleep.OperatorManaged,
+		name,
+		0,
+		k8s.noopSuspendOperatorManaged(namespace, kind, name),
+	).WithResource(apiVersion, kind).WithOwner(kubesleep.Owner{
+		APIVersion: owner.APIVersion,
+		Kind:       owner.Kind,
+		Name:       owner.Name,
+		Suspended:  suspended,
+	})
+	slog.Debug("parsed Suspendable", "Suspendable", s, "namespace", namespace)
+	return s
+}
+
+// suspendsOwner reports whether kubesleep suspends resources of the owner's kind,
+// either through a built-in integration, an annotation rule, a field rule or the generic /scale support.
+func (k8s K8Simpl) suspendsOwner(owner metav1.OwnerReference) bool {
+	groupKind := schema.FromAPIVersionAndKind(owner.APIVersion, owner.Kind).GroupKind()
+	return slices.Contains(builtinScaleKinds, groupKind) ||
+		hasAnnotationRule(groupKind) ||
+		k8s.hasFieldRule(groupKind) ||
+		groupKind == VIRTUAL_MACHINE_GVK.GroupKind() ||
+		isStrimziOwner(owner) ||
+		k8s.scalesGenerically(groupKind)
+}
+
+// scalesGenerically reports whether resources of the kind are scaled to zero through their /scale subresource by getScalables.
+func (k8s K8Simpl) scalesGenerically(groupKind schema.GroupKind) bool {
+	resources, err := k8s.discoverScalableResources()
+	if err != nil {
+		slog.Warn("Failed to discover scalable resources; assuming the owner isn't suspended", "kind", groupKind, "error", err)
+		return false
+	}
+	return slices.ContainsFunc(resources, func(resource scalableResource) bool {
+		return resource.gvk.GroupKind() == groupKind
+	})
+}
+
+func (k8s K8Simpl) noopSuspendOperatorManaged(namespace, kind, name string) func(context.Context) error {
+	return func(ctx context.Context) error {
+		slog.Debug("Workload is managed by an operator; skipping suspend", "namespace", namespace, "kind", kind, "name", name)
+		return nil
+	}
+}
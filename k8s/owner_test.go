@@ -0,0 +1,71 @@
+package k8s
+
+import (
+	kubesleep "github.com/Y0-L0/kubesleep/kubesleep"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/discovery/cached/memory"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	clienttesting "k8s.io/client-go/testing"
+	"k8s.io/utils/ptr"
+)
+
+func (s *Integrationtest) TestOperatorManaged_SkipsDeployment() {
+	deleteNamespace, err := testNamespace(s.ctx, "operator-managed", s.k8s, false)
+	s.Require().NoError(err)
+	defer deleteNamespace()
+
+	delete, err := CreateDeployment(s.ctx, *s.k8s, "operator-managed", "test-deployment", int32(2), metav1.OwnerReference{
+		APIVersion: "example.com/v1",
+		Kind:       "App",
+		Name:       "test-app",
+		UID:        "8e3c1f0a-2b4d-4c6e-9f7a-1d2e3f4a5b6c",
+		Controller: ptr.To(true),
+	})
+	s.Require().NoError(err)
+	defer delete()
+
+	suspendables, err := s.k8s.GetSuspendables(s.ctx, "operator-managed")
+	s.Require().NoError(err)
+	s.Require().NotContains(suspendables, "0:test-deployment")
+
+	actual := suspendables["20:Deployment.apps:test-deployment"]
+	s.Require().NoError(actual.Suspend(s.ctx))
+	actual.Suspend = nil
+	s.Require().Equal(
+		kubesleep.NewSuspendable(kubesleep.OperatorManaged, "test-deployment", 0, nil).
+			WithResource("apps/v1", "Deployment").
+			WithOwner(kubesleep.Owner{APIVersion: "example.com/v1", Kind: "App", Name: "test-app"}),
+		actual,
+	)
+	s.Require().NoError(s.k8s.ScaleSuspendable(s.ctx, "operator-managed", actual))
+
+	deployment, err := s.k8s.clientset.AppsV1().Deployments("operator-managed").Get(s.ctx, "test-deployment", metav1.GetOptions{})
+	s.Require().NoError(err)
+	s.Require().Equal(int32(2), *deployment.Spec.Replicas)
+}
+
+func (s *Unittest) TestSuspendsOwner() {
+	k8s := K8Simpl{
+		options: kubesleep.K8SOptions{FieldRules: []kubesleep.FieldRule{{APIVersion: "example.com/v1", Kind: "App"}}},
+		discovery: memory.NewMemCacheClient(&fakediscovery.FakeDiscovery{Fake: &clienttesting.Fake{Resources: []*metav1.APIResourceList{{
+			GroupVersion: "monitoring.coreos.com/v1",
+			APIResources: []metav1.APIResource{
+				{Name: "prometheuses", Kind: "Prometheus", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}},
+				{Name: "prometheuses/scale", Kind: "Scale", Namespaced: true, Verbs: metav1.Verbs{"get", "update"}},
+				{Name: "podmonitors", Kind: "PodMonitor", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}},
+			},
+		}}}}),
+	}
+
+	s.True(k8s.suspendsOwner(metav1.OwnerReference{APIVersion: "kafka.strimzi.io/v1beta2", Kind: "Kafka"}))
+	s.True(k8s.suspendsOwner(metav1.OwnerReference{APIVersion: "core.strimzi.io/v1beta2", Kind: "StrimziPodSet"}))
+	s.True(k8s.suspendsOwner(metav1.OwnerReference{APIVersion: "kubeflow.org/v1", Kind: "Notebook"}))
+	s.True(k8s.suspendsOwner(metav1.OwnerReference{APIVersion: "argoproj.io/v1alpha1", Kind: "Rollout"}))
+	s.True(k8s.suspendsOwner(metav1.OwnerReference{APIVersion: "example.com/v1", Kind: "App"}))
+	s.True(k8s.suspendsOwner(metav1.OwnerReference{APIVersion: "monitoring.coreos.com/v1", Kind: "Prometheus"}))
+	s.False(k8s.suspendsOwner(metav1.OwnerReference{APIVersion: "monitoring.coreos.com/v1", Kind: "PodMonitor"}))
+	s.False(k8s.suspendsOwner(metav1.OwnerReference{APIVersion: "example.com/v1", Kind: "Other"}))
+
+	k8s.options.ScaleDeny = []string{"Prometheus.monitoring.coreos.com"}
+	s.False(k8s.suspendsOwner(metav1.OwnerReference{APIVersion: "monitoring.coreos.com/v1", Kind: "Prometheus"}))
+}
@@ -0,0 +1,132 @@
+package k8s
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os/exec"
+	"strings"
+
+	kubesleep "github.com/Y0-L0/kubesleep/kubesleep"
+)
+
+// pluginState records which plugin manages a suspendable next to the opaque state returned by the plugin.
+type pluginState struct {
+	Plugin string          `json:"plugin"`
+	State  json.RawMessage `json:"state,omitempty"`
+}
+
+// getPluginSuspendables asks every discovered plugin for the resources it suspends in the namespace.
+func (k8s K8Simpl) getPluginSuspendables(ctx context.Context, namespace string) (map[string]kubesleep.Suspendable, error) {
+	suspendables := map[string]kubesleep.Suspendable{}
+
+	for _, plugin := range k8s.options.Plugins {
+		response, err := runPlugin(ctx, plugin, kubesleep.PluginRequest{Operation: kubesleep.PluginList, Namespace: namespace})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, resource := range response.Resources {
+			if resource.Kind == "" || resource.Name == "" {
+				return nil, fmt.Errorf("plugin %s returned a resource without kind or name", plugin.Name)
+			}
+			state, err := json.Marshal(pluginState{plugin.Name, resource.State})
+			if err != nil {
+				return nil, err
+			}
+
+			s := kubesleep.NewSuspendable(
+				kubesleep.PluginBased,
+				resource.Name,
+				resource.Replicas,
+				k8s.suspendPluginResource(namespace, plugin, resource),
+			).WithResource(resource.APIVersion, resource.Kind).WithState(state)
+			if _, exists := suspendables[s.Identifier()]; exists {
+				return nil, fmt.Errorf("plugin %s returned %s %s, which is already managed by another plugin", plugin.Name, resource.Kind, resource.Name)
+			}
+			slog.Debug("parsed Suspendable", "Suspendable", s, "namespace", namespace, "plugin", plugin.Name)
+			suspendables[s.Identifier()] = s
+		}
+	}
+
+	return suspendables, nil
+}
+
+func (k8s K8Simpl) suspendPluginResource(namespace string, plugin kubesleep.Plugin, resource kubesleep.PluginResource) func(context.Context) error {
+	return func(ctx context.Context) error {
+		request := kubesleep.PluginRequest{Operation: kubesleep.PluginSuspend, Namespace: namespace, Resource: &resource}
+		if _, err := runPlugin(ctx, plugin, request); err != nil {
+			return err
+		}
+		slog.Info("Suspended resource by plugin", "plugin", plugin.Name, "kind", resource.Kind, "name", resource.Name, "namespace", namespace)
+		return nil
+	}
+}
+
+func (k8s K8Simpl) wakePluginResource(ctx context.Context, namespace string, suspendable kubesleep.Suspendable) error {
+	var state pluginState
+	if err := json.Unmarshal(suspendable.State(), &state); err != nil {
+		return fmt.Errorf("invalid plugin state: %w", err)
+	}
+
+	plugin, found := k8s.findPlugin(state.Plugin)
+	if !found {
+		return fmt.Errorf("plugin %s is not installed; add kubesleep-plugin-%s to the PATH", state.Plugin, state.Plugin)
+	}
+
+	resource := kubesleep.PluginResource{
+		APIVersion: suspendable.APIVersion(),
+		Kind:       suspendable.Kind(),
+		Name:       suspendable.Name(),
+		Replicas:   suspendable.Replicas,
+		State:      state.State,
+	}
+	request := kubesleep.PluginRequest{Operation: kubesleep.PluginWake, Namespace: namespace, Resource: &resource}
+	if _, err := runPlugin(ctx, plugin, request); err != nil {
+		return err
+	}
+	slog.Info("Woke up resource by plugin", "plugin", plugin.Name, "kind", resource.Kind, "name", resource.Name, "namespace", namespace)
+	return nil
+}
+
+func (k8s K8Simpl) findPlugin(name string) (kubesleep.Plugin, bool) {
+	for _, plugin := range k8s.options.Plugins {
+		if plugin.Name == name {
+			return plugin, true
+		}
+	}
+	return kubesleep.Plugin{}, false
+}
+
+// runPlugin executes a plugin with the request on stdin and decodes the response from stdout.
+func runPlugin(ctx context.Context, plugin kubesleep.Plugin, request kubesleep.PluginRequest) (kubesleep.PluginResponse, error) {
+	var response kubesleep.PluginResponse
+	input, err := json.Marshal(request)
+	if err != nil {
+		return response, err
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, plugin.Path)
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	slog.Debug("Running plugin", "plugin", plugin.Name, "operation", request.Operation, "namespace", request.Namespace)
+	if err := cmd.Run(); err != nil {
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return response, fmt.Errorf("plugin %s failed to %s: %w: %s", plugin.Name, request.Operation, err, message)
+		}
+		return response, fmt.Errorf("plugin %s failed to %s: %w", plugin.Name, request.Operation, err)
+	}
+
+	if len(bytes.TrimSpace(stdout.Bytes())) == 0 {
+		return response, nil
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &response); err != nil {
+		return response, fmt.Errorf("plugin %s returned an invalid response to %s: %w", plugin.Name, request.Operation, err)
+	}
+	return response, nil
+}
@@ -0,0 +1,93 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+
+	kubesleep "github.com/Y0-L0/kubesleep/kubesleep"
+)
+
+// writePlugin creates a plugin executable that records its requests in requests.log
+// and answers list requests with the given response.
+func (s *Unittest) writePlugin(name, listResponse string) (kubesleep.Plugin, string) {
+	dir := s.T().TempDir()
+	log := filepath.Join(dir, "requests.log")
+	script := `#!/bin/sh
+request=$(cat)
+echo "$request" >> ` + log + `
+case "$request" in
+  *'"operation":"list"'*) echo '` + listResponse + `' ;;
+  *'"name":"broken"'*) echo "backend unavailable" >&2; exit 1 ;;
+esac
+`
+	path := filepath.Join(dir, "kubesleep-plugin-"+name)
+	s.Require().NoError(os.WriteFile(path, []byte(script), 0o700))
+	return kubesleep.Plugin{Name: name, Path: path}, log
+}
+
+func (s *Unittest) readPluginRequests(log string) []kubesleep.PluginRequest {
+	data, err := os.ReadFile(log)
+	s.Require().NoError(err)
+	var requests []kubesleep.PluginRequest
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		var request kubesleep.PluginRequest
+		s.Require().NoError(json.Unmarshal([]byte(line), &request))
+		requests = append(requests, request)
+	}
+	return requests
+}
+
+func (s *Unittest) TestPlugin_SuspendAndWake() {
+	plugin, log := s.writePlugin("queues", `{"resources":[{"apiVersion":"queues.example.com/v1","kind":"Queue","name":"orders","replicas":2,"state":{"consumers":2}}]}`)
+	k8s := K8Simpl{options: kubesleep.K8SOptions{Plugins: []kubesleep.Plugin{plugin}}}
+
+	suspendables, err := k8s.getPluginSuspendables(context.TODO(), "dev")
+	s.Require().NoError(err)
+	queue, ok := suspendables["22:Queue.queues.example.com:orders"]
+	s.Require().True(ok)
+	s.Require().Equal(int32(2), queue.Replicas)
+	s.Require().JSONEq(`{"plugin":"queues","state":{"consumers":2}}`, string(queue.State()))
+
+	s.Require().NoError(queue.Suspend(context.TODO()))
+	s.Require().NoError(k8s.ScaleSuspendable(context.TODO(), "dev", queue))
+
+	resource := &kubesleep.PluginResource{
+		APIVersion: "queues.example.com/v1",
+		Kind:       "Queue",
+		Name:       "orders",
+		Replicas:   2,
+		State:      json.RawMessage(`{"consumers":2}`),
+	}
+	s.Require().Equal([]kubesleep.PluginRequest{
+		{Operation: kubesleep.PluginList, Namespace: "dev"},
+		{Operation: kubesleep.PluginSuspend, Namespace: "dev", Resource: resource},
+		{Operation: kubesleep.PluginWake, Namespace: "dev", Resource: resource},
+	}, s.readPluginRequests(log))
+}
+
+func (s *Unittest) TestPlugin_Errors() {
+	plugin, _ := s.writePlugin("queues", `{"resources":[{"kind":"Queue","name":"broken"}]}`)
+	k8s := K8Simpl{options: kubesleep.K8SOptions{Plugins: []kubesleep.Plugin{plugin}}}
+
+	suspendables, err := k8s.getPluginSuspendables(context.TODO(), "dev")
+	s.Require().NoError(err)
+	s.Require().ErrorContains(suspendables["22:Queue:broken"].Suspend(context.TODO()), "plugin queues failed to suspend: exit status 1: backend unavailable")
+
+	missing := kubesleep.NewSuspendable(kubesleep.PluginBased, "orders", 1, nil).
+		WithResource("v1", "Queue").
+		WithState(json.RawMessage(`{"plugin":"uninstalled"}`))
+	s.Require().ErrorContains(k8s.ScaleSuspendable(context.TODO(), "dev", missing), "plugin uninstalled is not installed")
+
+	invalid, _ := s.writePlugin("invalid", `not json`)
+	k8s.options.Plugins = []kubesleep.Plugin{invalid}
+	_, err = k8s.getPluginSuspendables(context.TODO(), "dev")
+	s.Require().ErrorContains(err, "plugin invalid returned an invalid response to list")
+
+	duplicate, _ := s.writePlugin("duplicate", `{"resources":[{"kind":"Queue","name":"broken"}]}`)
+	k8s.options.Plugins = []kubesleep.Plugin{plugin, duplicate}
+	_, err = k8s.getPluginSuspendables(context.TODO(), "dev")
+	s.Require().ErrorContains(err, "already managed by another plugin")
+}
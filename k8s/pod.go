@@ -0,0 +1,127 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	kubesleep "github.com/Y0-L0/kubesleep/kubesleep"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// getBarePods returns a suspendable for every running Pod without ownerReferences.
+// The Pod manifest is saved in the state so the Pod can be recreated on wake.
+func (k8s K8Simpl) getBarePods(ctx context.Context, namespace string) (map[string]kubesleep.Suspendable, error) {
+	if !k8s.options.BarePods {
+		return nil, nil
+	}
+
+	pods, err := k8s.clientset.CoreV1().
+		Pods(namespace).
+		List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	suspendables := map[string]kubesleep.Suspendable{}
+
+	for _, pod := range pods.Items {
+		if len(pod.OwnerReferences) > 0 || pod.DeletionTimestamp != nil {
+			continue
+		}
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			slog.Debug("Skipping finished bare Pod", "namespace", namespace, "name", pod.Name)
+			continue
+		}
+		if hasEmptyDir(pod) {
+			if !k8s.options.DiscardEmptyDir {
+				return nil, fmt.Errorf("bare Pod %s in namespace %s uses an emptyDir volume whose data would be lost. Use --discard-emptydir to suspend it anyway", pod.Name, namespace)
+			}
+			slog.Warn("Suspending bare Pod with an emptyDir volume; its data will be lost", "namespace", namespace, "name", pod.Name)
+		}
+
+		state, err := json.Marshal(stripPod(pod))
+		if err != nil {
+			return nil, err
+		}
+
+		s := kubesleep.NewSuspendable(
+			kubesleep.BarePod,
+			pod.Name,
+			1,
+			k8s.suspendBarePod(namespace, pod.Name, pod.UID),
+		).WithState(state)
+		slog.Debug("parsed Suspendable", "Suspendable", s, "namespace", namespace)
+		suspendables[s.Identifier()] = s
+	}
+
+	return suspendables, nil
+}
+
+func hasEmptyDir(pod corev1.Pod) bool {
+	for _, volume := range pod.Spec.Volumes {
+		if volume.EmptyDir != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// stripPod removes everything from a Pod the API server sets itself or rejects on creation.
+func stripPod(pod corev1.Pod) corev1.Pod {
+	spec := *pod.Spec.DeepCopy()
+	spec.NodeName = ""
+	spec.EphemeralContainers = nil
+
+	return corev1.Pod{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        pod.Name,
+			Labels:      pod.Labels,
+			Annotations: pod.Annotations,
+			Finalizers:  pod.Finalizers,
+		},
+		Spec: spec,
+	}
+}
+
+func (k8s K8Simpl) suspendBarePod(namespace, name string, uid types.UID) func(context.Context) error {
+	return func(ctx context.Context) error {
+		// The UID precondition protects a Pod that was recreated under the same name in the meantime.
+		err := k8s.clientset.CoreV1().Pods(namespace).Delete(ctx, name, metav1.DeleteOptions{
+			Preconditions: metav1.NewUIDPreconditions(string(uid)),
+		})
+		if apierrors.IsNotFound(err) {
+			slog.Debug("Bare Pod already deleted; skipping suspend", "namespace", namespace, "name", name)
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		slog.Info("Suspended bare Pod", "name", name, "namespace", namespace)
+		return nil
+	}
+}
+
+func (k8s K8Simpl) wakeBarePod(ctx context.Context, namespace string, suspendable kubesleep.Suspendable) error {
+	var pod corev1.Pod
+	if err := json.Unmarshal(suspendable.State(), &pod); err != nil {
+		return fmt.Errorf("invalid bare Pod state: %w", err)
+	}
+	pod.Namespace = namespace
+
+	_, err := k8s.clientset.CoreV1().Pods(namespace).Create(ctx, &pod, metav1.CreateOptions{})
+	if apierrors.IsAlreadyExists(err) {
+		slog.Warn("Pod with the same name already exists; skipping wake", "name", pod.Name, "namespace", namespace)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	slog.Info("Woke up bare Pod", "name", pod.Name, "namespace", namespace)
+	return nil
+}
@@ -0,0 +1,149 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func CreatePod(ctx context.Context, k8s K8Simpl, namespace string, name string, volumes ...corev1.Volume) (func() error, error) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    map[string]string{"app": name},
+		},
+		Spec: corev1.PodSpec{
+			Volumes: volumes,
+			Containers: []corev1.Container{
+				{
+					Name:  name,
+					Image: "k8s.gcr.io/pause:3.9",
+				},
+			},
+		},
+	}
+
+	_, err := k8s.clientset.CoreV1().Pods(namespace).Create(ctx, pod, metav1.CreateOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	delete := func() error {
+		return k8s.clientset.CoreV1().Pods(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+	}
+	return delete, nil
+}
+
+func (s *Integrationtest) TestBarePods_DisabledByDefault() {
+	deleteNamespace, err := testNamespace(s.ctx, "bare-pods-disabled", s.k8s, false)
+	s.Require().NoError(err)
+	defer deleteNamespace()
+
+	delete, err := CreatePod(s.ctx, *s.k8s, "bare-pods-disabled", "test-pod")
+	s.Require().NoError(err)
+	defer delete()
+
+	suspendables, err := s.k8s.GetSuspendables(s.ctx, "bare-pods-disabled")
+	s.Require().NoError(err)
+	s.Require().NotContains(suspendables, "9:test-pod")
+}
+
+func (s *Integrationtest) TestBarePods_SuspendAndWake() {
+	deleteNamespace, err := testNamespace(s.ctx, "bare-pods-suspend", s.k8s, false)
+	s.Require().NoError(err)
+	defer deleteNamespace()
+
+	_, err = CreatePod(s.ctx, *s.k8s, "bare-pods-suspend", "test-pod")
+	s.Require().NoError(err)
+
+	k8s := *s.k8s
+	k8s.options.BarePods = true
+
+	suspendables, err := k8s.GetSuspendables(s.ctx, "bare-pods-suspend")
+	s.Require().NoError(err)
+	before, ok := suspendables["9:test-pod"]
+	s.Require().True(ok)
+	s.Require().Equal(int32(1), before.Replicas)
+	s.Require().NoError(before.Suspend(s.ctx))
+
+	_, err = s.k8s.clientset.CoreV1().Pods("bare-pods-suspend").Get(s.ctx, "test-pod", metav1.GetOptions{})
+	s.Require().True(apierrors.IsNotFound(err), "expected the Pod to be deleted, got %v", err)
+
+	s.Require().NoError(k8s.ScaleSuspendable(s.ctx, "bare-pods-suspend", before))
+
+	actual, err := s.k8s.clientset.CoreV1().Pods("bare-pods-suspend").Get(s.ctx, "test-pod", metav1.GetOptions{})
+	s.Require().NoError(err)
+	s.Require().Equal(map[string]string{"app": "test-pod"}, actual.Labels)
+	s.Require().Equal("k8s.gcr.io/pause:3.9", actual.Spec.Containers[0].Image)
+}
+
+func (s *Integrationtest) TestBarePods_RefuseEmptyDir() {
+	deleteNamespace, err := testNamespace(s.ctx, "bare-pods-emptydir", s.k8s, false)
+	s.Require().NoError(err)
+	defer deleteNamespace()
+
+	scratch := corev1.Volume{Name: "scratch", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}}
+	delete, err := CreatePod(s.ctx, *s.k8s, "bare-pods-emptydir", "test-pod", scratch)
+	s.Require().NoError(err)
+	defer delete()
+
+	k8s := *s.k8s
+	k8s.options.BarePods = true
+
+	_, err = k8s.GetSuspendables(s.ctx, "bare-pods-emptydir")
+	s.Require().ErrorContains(err, "emptyDir")
+
+	k8s.options.DiscardEmptyDir = true
+	suspendables, err := k8s.GetSuspendables(s.ctx, "bare-pods-emptydir")
+	s.Require().NoError(err)
+	s.Require().Contains(suspendables, "9:test-pod")
+}
+
+func (s *Unittest) TestStripPod() {
+	pod := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "debug",
+			Namespace:       "dev",
+			UID:             "1234",
+			ResourceVersion: "42",
+			Labels:          map[string]string{"run": "debug"},
+		},
+		Spec: corev1.PodSpec{
+			NodeName:            "node-1",
+			Containers:          []corev1.Container{{Name: "debug", Image: "busybox"}},
+			EphemeralContainers: []corev1.EphemeralContainer{{}},
+		},
+		Status: corev1.PodStatus{Phase: corev1.PodRunning, PodIP: "10.0.0.1"},
+	}
+
+	actual := stripPod(pod)
+
+	s.Require().Equal(corev1.Pod{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "debug",
+			Labels: map[string]string{"run": "debug"},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "debug", Image: "busybox"}},
+		},
+	}, actual)
+	s.Require().Equal("node-1", pod.Spec.NodeName, "stripPod must not modify its argument")
+
+	_, err := json.Marshal(actual)
+	s.Require().NoError(err)
+}
+
+func (s *Unittest) TestHasEmptyDir() {
+	pod := corev1.Pod{Spec: corev1.PodSpec{Volumes: []corev1.Volume{
+		{Name: "config", VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{}}},
+	}}}
+	s.Require().False(hasEmptyDir(pod))
+
+	pod.Spec.Volumes = append(pod.Spec.Volumes, corev1.Volume{Name: "scratch", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}})
+	s.Require().True(hasEmptyDir(pod))
+}
@@ -0,0 +1,231 @@
+package k8s
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+
+	kubesleep "github.com/Y0-L0/kubesleep/kubesleep"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/utils/ptr"
+)
+
+const lastAppliedConfiguration = "kubectl.kubernetes.io/last-applied-configuration"
+
+// recoveredSpec is the part of a workload spec kubesleep changes on suspend. A missing field has its default value.
+type recoveredSpec struct {
+	Replicas *int32 `json:"replicas"`
+	Suspend  *bool  `json:"suspend"`
+}
+
+type recoveredManifest struct {
+	Kind     string `json:"kind"`
+	Metadata struct {
+		Name string `json:"name"`
+	} `json:"metadata"`
+	Spec recoveredSpec `json:"spec"`
+}
+
+// helmRelease is the part of a Helm 3 release stored in a sh.helm.release.v1 Secret.
+type helmRelease struct {
+	Name     string `json:"name"`
+	Version  int    `json:"version"`
+	Manifest string `json:"manifest"`
+}
+
+type workloadKey struct {
+	kind string
+	name string
+}
+
+type helmSpec struct {
+	spec    recoveredSpec
+	release string
+}
+
+// RecoverSuspendables reconstructs the original replicas of Deployments and StatefulSets at zero replicas
+// and the original suspend flag of suspended CronJobs. The sources are tried in order: the manifest of the
+// deployed Helm release, the last-applied-configuration annotation of kubectl and the managedFields.
+// Workloads none of them knows about are returned without a source.
+func (k8s K8Simpl) RecoverSuspendables(ctx context.Context, namespace string) ([]kubesleep.RecoveredSuspendable, error) {
+	helmSpecs, err := k8s.getHelmSpecs(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []kubesleep.RecoveredSuspendable
+
+	deployments, err := k8s.clientset.AppsV1().Deployments(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, deployment := range deployments.Items {
+		if metav1.GetControllerOf(&deployment) != nil || ptr.Deref(deployment.Spec.Replicas, 1) != 0 {
+			continue
+		}
+		spec, source := recoverSpec(helmSpecs, "Deployment", deployment.ObjectMeta)
+		result = append(result, kubesleep.RecoveredSuspendable{
+			Suspendable: kubesleep.NewSuspendable(kubesleep.Deplyoment, deployment.Name, ptr.Deref(spec.Replicas, 1), nil),
+			Source:      source,
+		})
+	}
+
+	statefulSets, err := k8s.clientset.AppsV1().StatefulSets(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, statefulSet := range statefulSets.Items {
+		if metav1.GetControllerOf(&statefulSet) != nil || ptr.Deref(statefulSet.Spec.Replicas, 1) != 0 {
+			continue
+		}
+		spec, source := recoverSpec(helmSpecs, "StatefulSet", statefulSet.ObjectMeta)
+		result = append(result, kubesleep.RecoveredSuspendable{
+			Suspendable: kubesleep.NewSuspendable(kubesleep.StatefulSet, statefulSet.Name, ptr.Deref(spec.Replicas, 1), nil),
+			Source:      source,
+		})
+	}
+
+	cronJobs, err := k8s.clientset.BatchV1().CronJobs(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, cronJob := range cronJobs.Items {
+		if !ptr.Deref(cronJob.Spec.Suspend, false) {
+			continue
+		}
+		spec, source := recoverSpec(helmSpecs, "CronJob", cronJob.ObjectMeta)
+		result = append(result, kubesleep.RecoveredSuspendable{
+			Suspendable: kubesleep.NewSuspendable(kubesleep.CronJob, cronJob.Name, 0, nil).WithSuspended(ptr.Deref(spec.Suspend, false)),
+			Source:      source,
+		})
+	}
+
+	slog.Debug("Recovered suspendables", "namespace", namespace, "count", len(result))
+	return result, nil
+}
+
+// recoverSpec returns the original spec of a workload and a description of its source.
+// The source is empty if the workload couldn't be recovered.
+func recoverSpec(helmSpecs map[workloadKey]helmSpec, kind string, object metav1.ObjectMeta) (recoveredSpec, string) {
+	if helm, ok := helmSpecs[workloadKey{kind, object.Name}]; ok {
+		return helm.spec, fmt.Sprintf("Helm release %s", helm.release)
+	}
+
+	if lastApplied, ok := object.Annotations[lastAppliedConfiguration]; ok {
+		var manifest recoveredManifest
+		if err := json.Unmarshal([]byte(lastApplied), &manifest); err != nil {
+			slog.Warn("Ignoring invalid last-applied-configuration", "kind", kind, "name", object.Name, "error", err)
+		} else {
+			return manifest.Spec, "last-applied-configuration"
+		}
+	}
+
+	// managedFields don't contain values, but they show whether anyone besides the scale subresource ever set
+	// the replicas. If not, the workload ran with the default of one replica.
+	if kind != "CronJob" && !replicasSetExplicitly(object.ManagedFields) {
+		return recoveredSpec{}, "managedFields (replicas never set, default 1)"
+	}
+	return recoveredSpec{}, ""
+}
+
+func replicasSetExplicitly(managedFields []metav1.ManagedFieldsEntry) bool {
+	for _, entry := range managedFields {
+		if entry.Subresource != "" || entry.FieldsV1 == nil {
+			continue
+		}
+		var fields map[string]map[string]any
+		if err := json.Unmarshal(entry.FieldsV1.Raw, &fields); err != nil {
+			return true
+		}
+		if _, ok := fields["f:spec"]["f:replicas"]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// getHelmSpecs reads the workloads of the latest deployed revision of every Helm release in the namespace.
+func (k8s K8Simpl) getHelmSpecs(ctx context.Context, namespace string) (map[workloadKey]helmSpec, error) {
+	secrets, err := k8s.clientset.CoreV1().Secrets(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: "owner=helm,status=deployed",
+	})
+	if apierrors.IsForbidden(err) {
+		slog.Warn("Missing permissions to list Secrets; recovering without Helm releases", "namespace", namespace)
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	latest := map[string]helmRelease{}
+	for _, secret := range secrets.Items {
+		if secret.Type != "helm.sh/release.v1" {
+			continue
+		}
+		release, err := decodeHelmRelease(secret)
+		if err != nil {
+			slog.Warn("Ignoring unreadable Helm release", "secret", secret.Name, "namespace", namespace, "error", err)
+			continue
+		}
+		if existing, ok := latest[release.Name]; !ok || release.Version > existing.Version {
+			latest[release.Name] = release
+		}
+	}
+
+	result := map[workloadKey]helmSpec{}
+	for _, release := range latest {
+		manifests, err := parseManifests(release.Manifest)
+		if err != nil {
+			slog.Warn("Ignoring unparsable Helm release manifest", "release", release.Name, "namespace", namespace, "error", err)
+			continue
+		}
+		for _, manifest := range manifests {
+			result[workloadKey{manifest.Kind, manifest.Metadata.Name}] = helmSpec{manifest.Spec, release.Name}
+		}
+	}
+	return result, nil
+}
+
+// decodeHelmRelease decodes the release field of a Helm Secret: base64 encoded, gzip compressed JSON.
+func decodeHelmRelease(secret corev1.Secret) (helmRelease, error) {
+	var release helmRelease
+	data, err := base64.StdEncoding.DecodeString(string(secret.Data["release"]))
+	if err != nil {
+		return release, err
+	}
+	reader, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return release, err
+	}
+	defer reader.Close()
+	err = json.NewDecoder(reader).Decode(&release)
+	return release, err
+}
+
+func parseManifests(manifest string) ([]recoveredManifest, error) {
+	decoder := utilyaml.NewYAMLOrJSONDecoder(strings.NewReader(manifest), 4096)
+	var result []recoveredManifest
+	for {
+		var object recoveredManifest
+		err := decoder.Decode(&object)
+		if errors.Is(err, io.EOF) {
+			return result, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if object.Kind != "" {
+			result = append(result, object)
+		}
+	}
+}
@@ -0,0 +1,173 @@
+package k8s
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/json"
+
+	kubesleep "github.com/Y0-L0/kubesleep/kubesleep"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+const helmManifest = `---
+# Source: web/templates/deployment.yaml
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+spec:
+  replicas: 3
+---
+# Source: web/templates/cronjob.yaml
+apiVersion: batch/v1
+kind: CronJob
+metadata:
+  name: report
+spec:
+  suspend: false
+`
+
+func helmReleaseSecret(name string, version int, manifest string) (corev1.Secret, error) {
+	release, err := json.Marshal(helmRelease{Name: name, Version: version, Manifest: manifest})
+	if err != nil {
+		return corev1.Secret{}, err
+	}
+	var compressed bytes.Buffer
+	writer := gzip.NewWriter(&compressed)
+	if _, err := writer.Write(release); err != nil {
+		return corev1.Secret{}, err
+	}
+	if err := writer.Close(); err != nil {
+		return corev1.Secret{}, err
+	}
+	return corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "sh.helm.release.v1." + name,
+			Labels: map[string]string{"owner": "helm", "status": "deployed", "name": name},
+		},
+		Type: "helm.sh/release.v1",
+		Data: map[string][]byte{"release": []byte(base64.StdEncoding.EncodeToString(compressed.Bytes()))},
+	}, nil
+}
+
+func (s *Integrationtest) TestRecoverSuspendables() {
+	deleteNamespace, err := testNamespace(s.ctx, "recover", s.k8s, false)
+	s.Require().NoError(err)
+	defer deleteNamespace()
+
+	secret, err := helmReleaseSecret("web", 2, helmManifest)
+	s.Require().NoError(err)
+	_, err = s.k8s.clientset.CoreV1().Secrets("recover").Create(s.ctx, &secret, metav1.CreateOptions{})
+	s.Require().NoError(err)
+	_, err = CreateDeployment(s.ctx, *s.k8s, "recover", "web", 0)
+	s.Require().NoError(err)
+	_, err = CreateDeployment(s.ctx, *s.k8s, "recover", "running", 2)
+	s.Require().NoError(err)
+	_, err = CreateCronJob(s.ctx, *s.k8s, "recover", "report", true)
+	s.Require().NoError(err)
+
+	recovered, err := s.k8s.RecoverSuspendables(s.ctx, "recover")
+	s.Require().NoError(err)
+
+	s.Require().ElementsMatch(
+		[]kubesleep.RecoveredSuspendable{
+			{Suspendable: kubesleep.NewSuspendable(kubesleep.Deplyoment, "web", 3, nil), Source: "Helm release web"},
+			{Suspendable: kubesleep.NewSuspendable(kubesleep.CronJob, "report", 0, nil).WithSuspended(false), Source: "Helm release web"},
+		},
+		recovered,
+	)
+}
+
+func (s *Unittest) TestDecodeHelmRelease() {
+	secret, err := helmReleaseSecret("web", 4, helmManifest)
+	s.Require().NoError(err)
+
+	release, err := decodeHelmRelease(secret)
+
+	s.Require().NoError(err)
+	s.Require().Equal(helmRelease{Name: "web", Version: 4, Manifest: helmManifest}, release)
+}
+
+func (s *Unittest) TestDecodeHelmRelease_Invalid() {
+	_, err := decodeHelmRelease(corev1.Secret{Data: map[string][]byte{"release": []byte("not base64!")}})
+	s.Require().Error(err)
+
+	_, err = decodeHelmRelease(corev1.Secret{Data: map[string][]byte{"release": []byte(base64.StdEncoding.EncodeToString([]byte("not gzip")))}})
+	s.Require().Error(err)
+}
+
+func (s *Unittest) TestParseManifests() {
+	manifests, err := parseManifests(helmManifest + "---\n# Source: web/templates/empty.yaml\n")
+
+	s.Require().NoError(err)
+	s.Require().Len(manifests, 2)
+	s.Require().Equal("Deployment", manifests[0].Kind)
+	s.Require().Equal("web", manifests[0].Metadata.Name)
+	s.Require().Equal(ptr.To(int32(3)), manifests[0].Spec.Replicas)
+	s.Require().Equal("CronJob", manifests[1].Kind)
+	s.Require().Equal(ptr.To(false), manifests[1].Spec.Suspend)
+}
+
+func (s *Unittest) TestRecoverSpec() {
+	helmSpecs := map[workloadKey]helmSpec{
+		{"Deployment", "web"}: {recoveredSpec{Replicas: ptr.To(int32(3))}, "web"},
+	}
+	replicasByKubectl := []metav1.ManagedFieldsEntry{{
+		Manager:  "kubectl-client-side-apply",
+		FieldsV1: &metav1.FieldsV1{Raw: []byte(`{"f:spec":{"f:replicas":{},"f:template":{}}}`)},
+	}}
+	replicasByScale := []metav1.ManagedFieldsEntry{
+		{Manager: "helm", FieldsV1: &metav1.FieldsV1{Raw: []byte(`{"f:spec":{"f:template":{}}}`)}},
+		{Manager: "kubesleep", Subresource: "scale", FieldsV1: &metav1.FieldsV1{Raw: []byte(`{"f:spec":{"f:replicas":{}}}`)}},
+	}
+
+	tests := []struct {
+		name   string
+		kind   string
+		object metav1.ObjectMeta
+		spec   recoveredSpec
+		source string
+	}{
+		{
+			name:   "helm release wins over last-applied-configuration",
+			kind:   "Deployment",
+			object: metav1.ObjectMeta{Name: "web", Annotations: map[string]string{lastAppliedConfiguration: `{"spec":{"replicas":5}}`}},
+			spec:   recoveredSpec{Replicas: ptr.To(int32(3))},
+			source: "Helm release web",
+		},
+		{
+			name:   "last-applied-configuration",
+			kind:   "Deployment",
+			object: metav1.ObjectMeta{Name: "api", Annotations: map[string]string{lastAppliedConfiguration: `{"spec":{"replicas":5}}`}, ManagedFields: replicasByKubectl},
+			spec:   recoveredSpec{Replicas: ptr.To(int32(5))},
+			source: "last-applied-configuration",
+		},
+		{
+			name:   "invalid last-applied-configuration falls through to managedFields",
+			kind:   "StatefulSet",
+			object: metav1.ObjectMeta{Name: "db", Annotations: map[string]string{lastAppliedConfiguration: `{`}, ManagedFields: replicasByScale},
+			source: "managedFields (replicas never set, default 1)",
+		},
+		{
+			name:   "replicas set explicitly by an unknown source",
+			kind:   "Deployment",
+			object: metav1.ObjectMeta{Name: "api", ManagedFields: replicasByKubectl},
+		},
+		{
+			name:   "cronjob without a source",
+			kind:   "CronJob",
+			object: metav1.ObjectMeta{Name: "report"},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			spec, source := recoverSpec(helmSpecs, tt.kind, tt.object)
+			s.Require().Equal(tt.spec, spec)
+			s.Require().Equal(tt.source, source)
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package k8s
+
+import (
+	"context"
+	"log/slog"
+
+	kubesleep "github.com/Y0-L0/kubesleep/kubesleep"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func (k8s K8Simpl) getReplicaSets(ctx context.Context, namespace string) (map[string]kubesleep.Suspendable, error) {
+	replicaSets, err := k8s.clientset.AppsV1().
+		ReplicaSets(namespace).
+		List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	suspendables := map[string]kubesleep.Suspendable{}
+
+	for _, replicaSet := range replicaSets.Items {
+		// ReplicaSets of a Deployment are scaled by their Deployment.
+		if owner := metav1.GetControllerOf(&replicaSet); owner != nil {
+			slog.Debug("Skipping ReplicaSet managed by a controller", "namespace", namespace, "name", replicaSet.Name, "ownerKind", owner.Kind, "owner", owner.Name)
+			continue
+		}
+
+		replicas := ptr.Deref(replicaSet.Spec.Replicas, 1)
+		var suspend func(context.Context) error
+		if replicas == 0 {
+			suspend = k8s.noopSuspendReplicaSet(namespace, replicaSet.Name)
+		} else {
+			suspend = k8s.suspendReplicaSet(namespace, replicaSet.Name)
+		}
+
+		s := kubesleep.NewSuspendable(
+			kubesleep.ReplicaSet,
+			replicaSet.Name,
+			replicas,
+			suspend,
+		)
+		slog.Debug("parsed Suspendable", "Suspendable", s, "namespace", namespace)
+		suspendables[s.Identifier()] = s
+	}
+
+	return suspendables, nil
+}
+
+func (k8s K8Simpl) noopSuspendReplicaSet(namespace, name string) func(context.Context) error {
+	return func(ctx context.Context) error {
+		slog.Debug("ReplicaSet already at 0 replicas; skipping suspend", "namespace", namespace, "name", name)
+		return nil
+	}
+}
+
+func (k8s K8Simpl) suspendReplicaSet(namespace string, name string) func(context.Context) error {
+	return func(ctx context.Context) error {
+		if err := k8s.updateReplicaSetScale(ctx, namespace, name, 0); err != nil {
+			return err
+		}
+		slog.Info("Suspended ReplicaSet", "name", name, "namespace", namespace)
+		return nil
+	}
+}
+
+func (k8s K8Simpl) scaleReplicaSet(ctx context.Context, namespace string, name string, replicas int32) error {
+	if err := k8s.updateReplicaSetScale(ctx, namespace, name, replicas); err != nil {
+		return err
+	}
+	slog.Info("Woke up ReplicaSet", "namespace", namespace, "name", name)
+	return nil
+}
+
+func (k8s K8Simpl) updateReplicaSetScale(ctx context.Context, namespace string, name string, replicas int32) error {
+	scalable, err := k8s.clientset.AppsV1().
+		ReplicaSets(namespace).
+		GetScale(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	scalable.Spec.Replicas = replicas
+	_, err = k8s.clientset.AppsV1().ReplicaSets(namespace).UpdateScale(
+		ctx,
+		name,
+		scalable,
+		metav1.UpdateOptions{},
+	)
+	return err
+}
@@ -0,0 +1,117 @@
+package k8s
+
+import (
+	"context"
+
+	kubesleep "github.com/Y0-L0/kubesleep/kubesleep"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func CreateReplicaSet(ctx context.Context, k8s K8Simpl, namespace string, name string, replicas int32, owners ...metav1.OwnerReference) (func() error, error) {
+	labels := map[string]string{"app": name}
+
+	replicaSet := &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			Namespace:       namespace,
+			OwnerReferences: owners,
+		},
+		Spec: appsv1.ReplicaSetSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: labels,
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name:  name,
+							Image: "k8s.gcr.io/pause:3.9",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	_, err := k8s.clientset.AppsV1().ReplicaSets(namespace).Create(ctx, replicaSet, metav1.CreateOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	delete := func() error {
+		return k8s.clientset.AppsV1().ReplicaSets(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+	}
+	return delete, nil
+}
+
+func (s *Integrationtest) TestGetReplicaSet() {
+	deleteNamespace, err := testNamespace(s.ctx, "get-replicasets", s.k8s, false)
+	s.Require().NoError(err)
+	defer deleteNamespace()
+
+	delete, err := CreateReplicaSet(s.ctx, *s.k8s, "get-replicasets", "test-replicaset", int32(2))
+	s.Require().NoError(err)
+	defer delete()
+
+	actual := s.getSuspendable("get-replicasets", "7:test-replicaset")
+	actual.Suspend = nil
+	s.Require().Equal(
+		kubesleep.NewSuspendable(
+			kubesleep.ReplicaSet,
+			"test-replicaset",
+			int32(2),
+			nil,
+		),
+		actual,
+	)
+}
+
+func (s *Integrationtest) TestSuspendAndScaleReplicaSet() {
+	deleteNamespace, err := testNamespace(s.ctx, "suspend-replicasets", s.k8s, false)
+	s.Require().NoError(err)
+	defer deleteNamespace()
+
+	delete, err := CreateReplicaSet(s.ctx, *s.k8s, "suspend-replicasets", "test-replicaset", int32(2))
+	s.Require().NoError(err)
+	defer delete()
+
+	before := s.getSuspendable("suspend-replicasets", "7:test-replicaset")
+	s.Require().NoError(before.Suspend(s.ctx))
+
+	suspended := s.getSuspendable("suspend-replicasets", "7:test-replicaset")
+	s.Require().Equal(int32(0), suspended.Replicas)
+
+	err = s.k8s.ScaleSuspendable(s.ctx, "suspend-replicasets", before)
+	s.Require().NoError(err)
+
+	actual := s.getSuspendable("suspend-replicasets", "7:test-replicaset")
+	s.Require().Equal(int32(2), actual.Replicas)
+}
+
+func (s *Integrationtest) TestSkipReplicaSetOwnedByDeployment() {
+	deleteNamespace, err := testNamespace(s.ctx, "skip-deployment-replicasets", s.k8s, false)
+	s.Require().NoError(err)
+	defer deleteNamespace()
+
+	deleteDeployment, err := CreateDeployment(s.ctx, *s.k8s, "skip-deployment-replicasets", "test-deployment", int32(2))
+	s.Require().NoError(err)
+	defer deleteDeployment()
+	deployment, err := s.k8s.clientset.AppsV1().Deployments("skip-deployment-replicasets").Get(s.ctx, "test-deployment", metav1.GetOptions{})
+	s.Require().NoError(err)
+
+	owner := *metav1.NewControllerRef(deployment, appsv1.SchemeGroupVersion.WithKind("Deployment"))
+	delete, err := CreateReplicaSet(s.ctx, *s.k8s, "skip-deployment-replicasets", "test-replicaset", int32(2), owner)
+	s.Require().NoError(err)
+	defer delete()
+
+	suspendables, err := s.k8s.GetSuspendables(s.ctx, "skip-deployment-replicasets")
+	s.Require().NoError(err)
+	s.Require().Contains(suspendables, "0:test-deployment")
+	s.Require().NotContains(suspendables, "7:test-replicaset")
+}
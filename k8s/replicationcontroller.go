@@ -0,0 +1,91 @@
+package k8s
+
+import (
+	"context"
+	"log/slog"
+
+	kubesleep "github.com/Y0-L0/kubesleep/kubesleep"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func (k8s K8Simpl) getReplicationControllers(ctx context.Context, namespace string) (map[string]kubesleep.Suspendable, error) {
+	replicationControllers, err := k8s.clientset.CoreV1().
+		ReplicationControllers(namespace).
+		List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	suspendables := map[string]kubesleep.Suspendable{}
+
+	for _, replicationController := range replicationControllers.Items {
+		// ReplicationControllers of a DeploymentConfig are scaled by their owner.
+		if owner := metav1.GetControllerOf(&replicationController); owner != nil {
+			slog.Debug("Skipping ReplicationController managed by a controller", "namespace", namespace, "name", replicationController.Name, "ownerKind", owner.Kind, "owner", owner.Name)
+			continue
+		}
+
+		replicas := ptr.Deref(replicationController.Spec.Replicas, 1)
+		var suspend func(context.Context) error
+		if replicas == 0 {
+			suspend = k8s.noopSuspendReplicationController(namespace, replicationController.Name)
+		} else {
+			suspend = k8s.suspendReplicationController(namespace, replicationController.Name)
+		}
+
+		s := kubesleep.NewSuspendable(
+			kubesleep.ReplicationController,
+			replicationController.Name,
+			replicas,
+			suspend,
+		)
+		slog.Debug("parsed Suspendable", "Suspendable", s, "namespace", namespace)
+		suspendables[s.Identifier()] = s
+	}
+
+	return suspendables, nil
+}
+
+func (k8s K8Simpl) noopSuspendReplicationController(namespace, name string) func(context.Context) error {
+	return func(ctx context.Context) error {
+		slog.Debug("ReplicationController already at 0 replicas; skipping suspend", "namespace", namespace, "name", name)
+		return nil
+	}
+}
+
+func (k8s K8Simpl) suspendReplicationController(namespace string, name string) func(context.Context) error {
+	return func(ctx context.Context) error {
+		if err := k8s.updateReplicationControllerScale(ctx, namespace, name, 0); err != nil {
+			return err
+		}
+		slog.Info("Suspended ReplicationController", "name", name, "namespace", namespace)
+		return nil
+	}
+}
+
+func (k8s K8Simpl) scaleReplicationController(ctx context.Context, namespace string, name string, replicas int32) error {
+	if err := k8s.updateReplicationControllerScale(ctx, namespace, name, replicas); err != nil {
+		return err
+	}
+	slog.Info("Woke up ReplicationController", "namespace", namespace, "name", name)
+	return nil
+}
+
+func (k8s K8Simpl) updateReplicationControllerScale(ctx context.Context, namespace string, name string, replicas int32) error {
+	scalable, err := k8s.clientset.CoreV1().
+		ReplicationControllers(namespace).
+		GetScale(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	scalable.Spec.Replicas = replicas
+	_, err = k8s.clientset.CoreV1().ReplicationControllers(namespace).UpdateScale(
+		ctx,
+		name,
+		scalable,
+		metav1.UpdateOptions{},
+	)
+	return err
+}
@@ -0,0 +1,91 @@
+package k8s
+
+import (
+	"context"
+
+	kubesleep "github.com/Y0-L0/kubesleep/kubesleep"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func CreateReplicationController(ctx context.Context, k8s K8Simpl, namespace string, name string, replicas int32) (func() error, error) {
+	labels := map[string]string{"app": name}
+
+	replicationController := &corev1.ReplicationController{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: corev1.ReplicationControllerSpec{
+			Replicas: &replicas,
+			Selector: labels,
+			Template: &corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name:  name,
+							Image: "k8s.gcr.io/pause:3.9",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	_, err := k8s.clientset.CoreV1().ReplicationControllers(namespace).Create(ctx, replicationController, metav1.CreateOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	delete := func() error {
+		return k8s.clientset.CoreV1().ReplicationControllers(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+	}
+	return delete, nil
+}
+
+func (s *Integrationtest) TestGetReplicationController() {
+	deleteNamespace, err := testNamespace(s.ctx, "get-replicationcontrollers", s.k8s, false)
+	s.Require().NoError(err)
+	defer deleteNamespace()
+
+	delete, err := CreateReplicationController(s.ctx, *s.k8s, "get-replicationcontrollers", "test-rc", int32(2))
+	s.Require().NoError(err)
+	defer delete()
+
+	actual := s.getSuspendable("get-replicationcontrollers", "8:test-rc")
+	actual.Suspend = nil
+	s.Require().Equal(
+		kubesleep.NewSuspendable(
+			kubesleep.ReplicationController,
+			"test-rc",
+			int32(2),
+			nil,
+		),
+		actual,
+	)
+}
+
+func (s *Integrationtest) TestSuspendAndScaleReplicationController() {
+	deleteNamespace, err := testNamespace(s.ctx, "suspend-replicationcontrollers", s.k8s, false)
+	s.Require().NoError(err)
+	defer deleteNamespace()
+
+	delete, err := CreateReplicationController(s.ctx, *s.k8s, "suspend-replicationcontrollers", "test-rc", int32(2))
+	s.Require().NoError(err)
+	defer delete()
+
+	before := s.getSuspendable("suspend-replicationcontrollers", "8:test-rc")
+	s.Require().NoError(before.Suspend(s.ctx))
+
+	suspended := s.getSuspendable("suspend-replicationcontrollers", "8:test-rc")
+	s.Require().Equal(int32(0), suspended.Replicas)
+
+	err = s.k8s.ScaleSuspendable(s.ctx, "suspend-replicationcontrollers", before)
+	s.Require().NoError(err)
+
+	actual := s.getSuspendable("suspend-replicationcontrollers", "8:test-rc")
+	s.Require().Equal(int32(2), actual.Replicas)
+}
@@ -0,0 +1,95 @@
+package k8s
+
+import (
+	"context"
+	"log/slog"
+
+	kubesleep "github.com/Y0-L0/kubesleep/kubesleep"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var ROLLOUT_GVK = schema.GroupVersionKind{Group: "argoproj.io", Version: "v1alpha1", Kind: "Rollout"}
+
+func (k8s K8Simpl) getRollouts(ctx context.Context, namespace string) (map[string]kubesleep.Suspendable, error) {
+	mapping, err := k8s.mapper.RESTMapping(ROLLOUT_GVK.GroupKind(), ROLLOUT_GVK.Version)
+	if meta.IsNoMatchError(err) {
+		slog.Debug("Argo Rollouts is not installed; skipping Rollouts", "namespace", namespace)
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rollouts, err := k8s.dynamic.Resource(mapping.Resource).
+		Namespace(namespace).
+		List(ctx, metav1.ListOptions{})
+	if apierrors.IsForbidden(err) {
+		slog.Warn("Missing permissions to list Rollouts; skipping them", "namespace", namespace)
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	suspendables := map[string]kubesleep.Suspendable{}
+
+	for _, rollout := range rollouts.Items {
+		scalable, err := k8s.scales.Scales(namespace).Get(ctx, mapping.Resource.GroupResource(), rollout.GetName(), metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+
+		var suspend func(context.Context) error
+		if scalable.Spec.Replicas == 0 {
+			suspend = k8s.noopSuspendRollout(namespace, rollout.GetName())
+		} else {
+			suspend = k8s.suspendRollout(namespace, mapping.Resource.GroupResource(), rollout.GetName())
+		}
+
+		s := kubesleep.NewSuspendable(
+			kubesleep.Rollout,
+			rollout.GetName(),
+			scalable.Spec.Replicas,
+			suspend,
+		)
+		slog.Debug("parsed Suspendable", "Suspendable", s, "namespace", namespace)
+		suspendables[s.Identifier()] = s
+	}
+
+	return suspendables, nil
+}
+
+func (k8s K8Simpl) noopSuspendRollout(namespace, name string) func(context.Context) error {
+	return func(ctx context.Context) error {
+		slog.Debug("Rollout already at 0 replicas; skipping suspend", "namespace", namespace, "name", name)
+		return nil
+	}
+}
+
+// suspendRollout only changes the replicas. Argo Rollouts starts a new canary or blue-green analysis
+// on pod template changes, so scaling neither creates a new revision nor triggers an analysis.
+func (k8s K8Simpl) suspendRollout(namespace string, resource schema.GroupResource, name string) func(context.Context) error {
+	return func(ctx context.Context) error {
+		if err := k8s.updateScale(ctx, namespace, resource, name, 0); err != nil {
+			return err
+		}
+		slog.Info("Suspended Rollout", "name", name, "namespace", namespace)
+		return nil
+	}
+}
+
+func (k8s K8Simpl) scaleRollout(ctx context.Context, namespace string, name string, replicas int32) error {
+	mapping, err := k8s.mapper.RESTMapping(ROLLOUT_GVK.GroupKind(), ROLLOUT_GVK.Version)
+	if err != nil {
+		return err
+	}
+
+	if err := k8s.updateScale(ctx, namespace, mapping.Resource.GroupResource(), name, replicas); err != nil {
+		return err
+	}
+	slog.Info("Woke up Rollout", "namespace", namespace, "name", name)
+	return nil
+}
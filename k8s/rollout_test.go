@@ -0,0 +1,53 @@
+package k8s
+
+import (
+	kubesleep "github.com/Y0-L0/kubesleep/kubesleep"
+)
+
+func (s *Integrationtest) TestRollout_Get() {
+	deleteNamespace, err := testNamespace(s.ctx, "get-rollouts", s.k8s, false)
+	s.Require().NoError(err)
+	defer deleteNamespace()
+
+	delete, err := CreateCustomResource(s.ctx, *s.k8s, ROLLOUT_GVR, "Rollout", "get-rollouts", "test-rollout", map[string]any{"replicas": int64(3)})
+	s.Require().NoError(err)
+	defer delete()
+
+	suspendables, err := s.k8s.GetSuspendables(s.ctx, "get-rollouts")
+	s.Require().NoError(err)
+	s.Require().NotContains(suspendables, "3:Rollout.argoproj.io:test-rollout", "Rollouts must not be suspended twice")
+
+	actual := suspendables["11:test-rollout"]
+	actual.Suspend = nil
+	s.Require().Equal(
+		kubesleep.NewSuspendable(
+			kubesleep.Rollout,
+			"test-rollout",
+			int32(3),
+			nil,
+		),
+		actual,
+	)
+}
+
+func (s *Integrationtest) TestRollout_SuspendAndScale() {
+	deleteNamespace, err := testNamespace(s.ctx, "suspend-rollouts", s.k8s, false)
+	s.Require().NoError(err)
+	defer deleteNamespace()
+
+	delete, err := CreateCustomResource(s.ctx, *s.k8s, ROLLOUT_GVR, "Rollout", "suspend-rollouts", "test-rollout", map[string]any{"replicas": int64(3)})
+	s.Require().NoError(err)
+	defer delete()
+
+	before := s.getSuspendable("suspend-rollouts", "11:test-rollout")
+	s.Require().NoError(before.Suspend(s.ctx))
+
+	suspended := s.getSuspendable("suspend-rollouts", "11:test-rollout")
+	s.Require().Equal(int32(0), suspended.Replicas)
+
+	err = s.k8s.ScaleSuspendable(s.ctx, "suspend-rollouts", before)
+	s.Require().NoError(err)
+
+	actual := s.getSuspendable("suspend-rollouts", "11:test-rollout")
+	s.Require().Equal(int32(3), actual.Replicas)
+}
@@ -0,0 +1,198 @@
+package k8s
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"strings"
+
+	kubesleep "github.com/Y0-L0/kubesleep/kubesleep"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+)
+
+// builtinScaleKinds have a dedicated suspendable and are excluded from the generic /scale support.
+var builtinScaleKinds = []schema.GroupKind{
+	{Group: "apps", Kind: "Deployment"},
+	{Group: "apps", Kind: "StatefulSet"},
+	{Group: "apps", Kind: "ReplicaSet"},
+	{Group: "", Kind: "ReplicationController"},
+	{Group: "argoproj.io", Kind: "Rollout"},
+	{Group: "postgresql.cnpg.io", Kind: "Cluster"},
+	{Group: "kafka.strimzi.io", Kind: "KafkaNodePool"},
+	{Group: "apps.openshift.io", Kind: "DeploymentConfig"},
+}
+
+type scalableResource struct {
+	gvk schema.GroupVersionKind
+	gvr schema.GroupVersionResource
+}
+
+func (k8s K8Simpl) getScalables(ctx context.Context, namespace string) (map[string]kubesleep.Suspendable, error) {
+	resources, err := k8s.discoverScalableResources()
+	if err != nil {
+		return nil, err
+	}
+
+	suspendables := map[string]kubesleep.Suspendable{}
+
+	for _, resource := range resources {
+		objects, err := k8s.dynamic.Resource(resource.gvr).
+			Namespace(namespace).
+			List(ctx, metav1.ListOptions{})
+		if apierrors.IsForbidden(err) {
+			slog.Warn("Missing permissions to list scalable resource; skipping it", "resource", resource.gvr, "namespace", namespace)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		for _, object := range objects.Items {
+			if owner := metav1.GetControllerOf(&object); owner != nil {
+				slog.Debug("Skipping scalable resource managed by a controller", "kind", resource.gvk.Kind, "name", object.GetName(), "owner", owner.Name, "namespace", namespace)
+				continue
+			}
+
+			scalable, err := k8s.scales.Scales(namespace).Get(ctx, resource.gvr.GroupResource(), object.GetName(), metav1.GetOptions{})
+			if err != nil {
+				return nil, err
+			}
+
+			var suspend func(context.Context) error
+			if scalable.Spec.Replicas == 0 {
+				suspend = k8s.noopSuspendScalable(namespace, resource.gvk.Kind, object.GetName())
+			} else {
+				suspend = k8s.suspendScalable(namespace, resource.gvr.GroupResource(), object.GetName())
+			}
+
+			s := kubesleep.NewSuspendable(
+				kubesleep.Scalable,
+				object.GetName(),
+				scalable.Spec.Replicas,
+				suspend,
+			).WithResource(resource.gvk.GroupVersion().String(), resource.gvk.Kind)
+			slog.Debug("parsed Suspendable", "Suspendable", s, "namespace", namespace)
+			suspendables[s.Identifier()] = s
+		}
+	}
+
+	return suspendables, nil
+}
+
+// discoverScalableResources lists the preferred version of every namespaced resource with a /scale subresource
+// that is neither handled by a dedicated suspendable, annotation rule or field rule nor excluded by the allow and deny lists.
+func (k8s K8Simpl) discoverScalableResources() ([]scalableResource, error) {
+	groups, resourceLists, err := k8s.discovery.ServerGroupsAndResources()
+	if discovery.IsGroupDiscoveryFailedError(err) {
+		slog.Warn("Failed to discover some api groups; their resources won't be suspended", "error", err)
+	} else if err != nil {
+		return nil, err
+	}
+
+	preferredVersions := map[string]string{}
+	for _, group := range groups {
+		preferredVersions[group.Name] = group.PreferredVersion.GroupVersion
+	}
+
+	var resources []scalableResource
+	for _, resourceList := range resourceLists {
+		groupVersion, err := schema.ParseGroupVersion(resourceList.GroupVersion)
+		if err != nil {
+			return nil, err
+		}
+		if preferredVersions[groupVersion.Group] != resourceList.GroupVersion {
+			continue
+		}
+
+		withScale := map[string]bool{}
+		for _, resource := range resourceList.APIResources {
+			if parent, found := strings.CutSuffix(resource.Name, "/scale"); found {
+				withScale[parent] = true
+			}
+		}
+
+		for _, resource := range resourceList.APIResources {
+			if !resource.Namespaced || !withScale[resource.Name] || !slices.Contains(resource.Verbs, "list") {
+				continue
+			}
+			gvk := groupVersion.WithKind(resource.Kind)
+			if !k8s.scaleAllowed(gvk) {
+				slog.Debug("Generic /scale support disabled for kind", "gvk", gvk)
+				continue
+			}
+			resources = append(resources, scalableResource{gvk, groupVersion.WithResource(resource.Name)})
+		}
+	}
+	return resources, nil
+}
+
+func (k8s K8Simpl) scaleAllowed(gvk schema.GroupVersionKind) bool {
+	if slices.Contains(builtinScaleKinds, gvk.GroupKind()) || k8s.hasFieldRule(gvk.GroupKind()) || hasAnnotationRule(gvk.GroupKind()) {
+		return false
+	}
+	if matchesAnyKind(k8s.options.ScaleDeny, gvk) {
+		return false
+	}
+	return len(k8s.options.ScaleAllow) == 0 || matchesAnyKind(k8s.options.ScaleAllow, gvk)
+}
+
+// matchesAnyKind reports whether gvk matches one of the kinds given in the kubectl notation
+// Kind.version.group (exact match) or Kind.group (any version).
+func matchesAnyKind(kinds []string, gvk schema.GroupVersionKind) bool {
+	for _, kind := range kinds {
+		fullySpecified, groupKind := schema.ParseKindArg(kind)
+		if fullySpecified != nil && *fullySpecified == gvk {
+			return true
+		}
+		if groupKind == gvk.GroupKind() {
+			return true
+		}
+	}
+	return false
+}
+
+func (k8s K8Simpl) noopSuspendScalable(namespace, kind, name string) func(context.Context) error {
+	return func(ctx context.Context) error {
+		slog.Debug("Scalable resource already at 0 replicas; skipping suspend", "namespace", namespace, "kind", kind, "name", name)
+		return nil
+	}
+}
+
+func (k8s K8Simpl) suspendScalable(namespace string, resource schema.GroupResource, name string) func(context.Context) error {
+	return func(ctx context.Context) error {
+		if err := k8s.updateScale(ctx, namespace, resource, name, 0); err != nil {
+			return err
+		}
+		slog.Info("Suspended scalable resource", "resource", resource, "name", name, "namespace", namespace)
+		return nil
+	}
+}
+
+func (k8s K8Simpl) scaleScalable(ctx context.Context, namespace string, suspendable kubesleep.Suspendable) error {
+	gvk := schema.FromAPIVersionAndKind(suspendable.APIVersion(), suspendable.Kind())
+	mapping, err := k8s.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return err
+	}
+
+	resource := mapping.Resource.GroupResource()
+	if err := k8s.updateScale(ctx, namespace, resource, suspendable.Name(), suspendable.WakeReplicas()); err != nil {
+		return err
+	}
+	slog.Info("Woke up scalable resource", "resource", resource, "name", suspendable.Name(), "namespace", namespace)
+	return nil
+}
+
+func (k8s K8Simpl) updateScale(ctx context.Context, namespace string, resource schema.GroupResource, name string, replicas int32) error {
+	scalable, err := k8s.scales.Scales(namespace).Get(ctx, resource, name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	scalable.Spec.Replicas = replicas
+	_, err = k8s.scales.Scales(namespace).Update(ctx, resource, scalable, metav1.UpdateOptions{})
+	return err
+}
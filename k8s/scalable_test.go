@@ -0,0 +1,144 @@
+package k8s
+
+import (
+	kubesleep "github.com/Y0-L0/kubesleep/kubesleep"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const WIDGET_KEY = "3:Widget.test.kubesleep.xyz:test-widget"
+
+func (s *Integrationtest) TestScalable_Get() {
+	deleteNamespace, err := testNamespace(s.ctx, "get-scalables", s.k8s, false)
+	s.Require().NoError(err)
+	defer deleteNamespace()
+
+	delete, err := CreateCustomResource(s.ctx, *s.k8s, WIDGET_GVR, "Widget", "get-scalables", "test-widget", map[string]any{"replicas": int64(3)})
+	s.Require().NoError(err)
+	defer delete()
+
+	actual := s.getSuspendable("get-scalables", WIDGET_KEY)
+	actual.Suspend = nil
+	s.Require().Equal(
+		kubesleep.NewSuspendable(
+			kubesleep.Scalable,
+			"test-widget",
+			int32(3),
+			nil,
+		).WithResource("test.kubesleep.xyz/v1", "Widget"),
+		actual,
+	)
+}
+
+func (s *Integrationtest) TestScalable_SuspendAndScale() {
+	deleteNamespace, err := testNamespace(s.ctx, "suspend-scalables", s.k8s, false)
+	s.Require().NoError(err)
+	defer deleteNamespace()
+
+	delete, err := CreateCustomResource(s.ctx, *s.k8s, WIDGET_GVR, "Widget", "suspend-scalables", "test-widget", map[string]any{"replicas": int64(3)})
+	s.Require().NoError(err)
+	defer delete()
+
+	before := s.getSuspendable("suspend-scalables", WIDGET_KEY)
+	s.Require().NoError(before.Suspend(s.ctx))
+
+	suspended := s.getSuspendable("suspend-scalables", WIDGET_KEY)
+	s.Require().Equal(int32(0), suspended.Replicas)
+
+	err = s.k8s.ScaleSuspendable(s.ctx, "suspend-scalables", before)
+	s.Require().NoError(err)
+
+	actual := s.getSuspendable("suspend-scalables", WIDGET_KEY)
+	s.Require().Equal(int32(3), actual.Replicas)
+}
+
+func (s *Integrationtest) TestScalable_SkipControllerOwned() {
+	deleteNamespace, err := testNamespace(s.ctx, "skip-owned-scalables", s.k8s, false)
+	s.Require().NoError(err)
+	defer deleteNamespace()
+
+	delete, err := CreateCustomResource(s.ctx, *s.k8s, WIDGET_GVR, "Widget", "skip-owned-scalables", "test-widget", map[string]any{"replicas": int64(3)})
+	s.Require().NoError(err)
+	defer delete()
+
+	widgets := s.k8s.dynamic.Resource(WIDGET_GVR).Namespace("skip-owned-scalables")
+	widget, err := widgets.Get(s.ctx, "test-widget", metav1.GetOptions{})
+	s.Require().NoError(err)
+	controller := true
+	widget.SetOwnerReferences([]metav1.OwnerReference{{
+		APIVersion: "test.kubesleep.xyz/v1",
+		Kind:       "WidgetOperator",
+		Name:       "operator",
+		UID:        "00000000-0000-0000-0000-000000000000",
+		Controller: &controller,
+	}})
+	_, err = widgets.Update(s.ctx, widget, metav1.UpdateOptions{})
+	s.Require().NoError(err)
+
+	suspendables, err := s.k8s.GetSuspendables(s.ctx, "skip-owned-scalables")
+	s.Require().NoError(err)
+	s.Require().NotContains(suspendables, WIDGET_KEY)
+}
+
+func (s *Integrationtest) TestScalable_Deny() {
+	deleteNamespace, err := testNamespace(s.ctx, "deny-scalables", s.k8s, false)
+	s.Require().NoError(err)
+	defer deleteNamespace()
+
+	delete, err := CreateCustomResource(s.ctx, *s.k8s, WIDGET_GVR, "Widget", "deny-scalables", "test-widget", map[string]any{"replicas": int64(3)})
+	s.Require().NoError(err)
+	defer delete()
+
+	k8s := *s.k8s
+	k8s.options = kubesleep.K8SOptions{ScaleDeny: []string{"Widget.test.kubesleep.xyz"}}
+	suspendables, err := k8s.GetSuspendables(s.ctx, "deny-scalables")
+	s.Require().NoError(err)
+	s.Require().NotContains(suspendables, WIDGET_KEY)
+}
+
+func (s *Unittest) TestMatchesAnyKind() {
+	gvk := schema.GroupVersionKind{Group: "apps.kruise.io", Version: "v1alpha1", Kind: "CloneSet"}
+	tests := []struct {
+		name     string
+		kinds    []string
+		expected bool
+	}{
+		{"no kinds", nil, false},
+		{"group kind", []string{"CloneSet.apps.kruise.io"}, true},
+		{"fully specified", []string{"CloneSet.v1alpha1.apps.kruise.io"}, true},
+		{"other version", []string{"CloneSet.v1beta1.apps.kruise.io"}, false},
+		{"other group", []string{"CloneSet.apps"}, false},
+		{"kind only", []string{"CloneSet"}, false},
+		{"second entry", []string{"Prometheus.monitoring.coreos.com", "CloneSet.apps.kruise.io"}, true},
+	}
+
+	for _, testCase := range tests {
+		s.Run(testCase.name, func() {
+			s.Require().Equal(testCase.expected, matchesAnyKind(testCase.kinds, gvk))
+		})
+	}
+}
+
+func (s *Unittest) TestScaleAllowed() {
+	cloneSet := schema.GroupVersionKind{Group: "apps.kruise.io", Version: "v1alpha1", Kind: "CloneSet"}
+	tests := []struct {
+		name     string
+		options  kubesleep.K8SOptions
+		gvk      schema.GroupVersionKind
+		expected bool
+	}{
+		{"allowed by default", kubesleep.K8SOptions{}, cloneSet, true},
+		{"builtin deployment", kubesleep.K8SOptions{}, schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}, false},
+		{"builtin rollout", kubesleep.K8SOptions{}, ROLLOUT_GVK, false},
+		{"not in allow list", kubesleep.K8SOptions{ScaleAllow: []string{"Prometheus.monitoring.coreos.com"}}, cloneSet, false},
+		{"in allow list", kubesleep.K8SOptions{ScaleAllow: []string{"CloneSet.apps.kruise.io"}}, cloneSet, true},
+		{"deny wins", kubesleep.K8SOptions{ScaleAllow: []string{"CloneSet.apps.kruise.io"}, ScaleDeny: []string{"CloneSet.apps.kruise.io"}}, cloneSet, false},
+	}
+
+	for _, testCase := range tests {
+		s.Run(testCase.name, func() {
+			k8s := K8Simpl{options: testCase.options}
+			s.Require().Equal(testCase.expected, k8s.scaleAllowed(testCase.gvk))
+		})
+	}
+}
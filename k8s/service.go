@@ -0,0 +1,197 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	kubesleep "github.com/Y0-L0/kubesleep/kubesleep"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// serviceState records the fields of a LoadBalancer Service that are dropped or rejected when it becomes a ClusterIP Service.
+type serviceState struct {
+	Type                          corev1.ServiceType                      `json:"type"`
+	Annotations                   map[string]string                       `json:"annotations,omitempty"`
+	LoadBalancerSourceRanges      []string                                `json:"loadBalancerSourceRanges,omitempty"`
+	LoadBalancerClass             *string                                 `json:"loadBalancerClass,omitempty"`
+	LoadBalancerIP                string                                  `json:"loadBalancerIP,omitempty"`
+	AllocateLoadBalancerNodePorts *bool                                   `json:"allocateLoadBalancerNodePorts,omitempty"`
+	ExternalTrafficPolicy         corev1.ServiceExternalTrafficPolicyType `json:"externalTrafficPolicy,omitempty"`
+	HealthCheckNodePort           int32                                   `json:"healthCheckNodePort,omitempty"`
+	NodePorts                     []servicePortState                      `json:"nodePorts,omitempty"`
+}
+
+// servicePortState identifies a port by its port number and protocol, which are unique within a Service.
+type servicePortState struct {
+	Port     int32           `json:"port"`
+	Protocol corev1.Protocol `json:"protocol"`
+	NodePort int32           `json:"nodePort"`
+}
+
+// getLoadBalancers returns a suspendable for every Service of type LoadBalancer if enabled.
+// Switching them to ClusterIP releases the cloud load balancer while keeping the Service usable inside the cluster.
+func (k8s K8Simpl) getLoadBalancers(ctx context.Context, namespace string) (map[string]kubesleep.Suspendable, error) {
+	if !k8s.options.LoadBalancers {
+		return nil, nil
+	}
+
+	services, err := k8s.clientset.CoreV1().
+		Services(namespace).
+		List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	suspendables := map[string]kubesleep.Suspendable{}
+
+	for _, service := range services.Items {
+		if service.Spec.Type != corev1.ServiceTypeLoadBalancer {
+			continue
+		}
+
+		state, err := json.Marshal(newServiceState(service))
+		if err != nil {
+			return nil, err
+		}
+
+		s := kubesleep.NewSuspendable(
+			kubesleep.LoadBalancer,
+			service.Name,
+			0,
+			k8s.suspendLoadBalancer(namespace, service.Name, service.UID),
+		).WithState(state)
+		slog.Debug("parsed Suspendable", "Suspendable", s, "namespace", namespace)
+		suspendables[s.Identifier()] = s
+	}
+
+	return suspendables, nil
+}
+
+func newServiceState(service corev1.Service) serviceState {
+	state := serviceState{
+		Type:                          service.Spec.Type,
+		Annotations:                   service.Annotations,
+		LoadBalancerSourceRanges:      service.Spec.LoadBalancerSourceRanges,
+		LoadBalancerClass:             service.Spec.LoadBalancerClass,
+		LoadBalancerIP:                service.Spec.LoadBalancerIP,
+		AllocateLoadBalancerNodePorts: service.Spec.AllocateLoadBalancerNodePorts,
+		ExternalTrafficPolicy:         service.Spec.ExternalTrafficPolicy,
+		HealthCheckNodePort:           service.Spec.HealthCheckNodePort,
+	}
+	for _, port := range service.Spec.Ports {
+		if port.NodePort != 0 {
+			state.NodePorts = append(state.NodePorts, servicePortState{port.Port, port.Protocol, port.NodePort})
+		}
+	}
+	return state
+}
+
+// toClusterIP clears every field the API server only accepts for NodePort and LoadBalancer Services.
+func toClusterIP(service *corev1.Service) {
+	service.Spec.Type = corev1.ServiceTypeClusterIP
+	service.Spec.LoadBalancerSourceRanges = nil
+	service.Spec.LoadBalancerClass = nil
+	service.Spec.LoadBalancerIP = ""
+	service.Spec.AllocateLoadBalancerNodePorts = nil
+	service.Spec.ExternalTrafficPolicy = ""
+	service.Spec.HealthCheckNodePort = 0
+	for i := range service.Spec.Ports {
+		service.Spec.Ports[i].NodePort = 0
+	}
+}
+
+// restore applies the recorded fields to the Service. Recorded annotations are set again, others are kept.
+// Node ports are only restored if withNodePorts is set.
+func (s serviceState) restore(service *corev1.Service, withNodePorts bool) {
+	service.Spec.Type = s.Type
+	service.Spec.LoadBalancerSourceRanges = s.LoadBalancerSourceRanges
+	service.Spec.LoadBalancerClass = s.LoadBalancerClass
+	service.Spec.LoadBalancerIP = s.LoadBalancerIP
+	service.Spec.AllocateLoadBalancerNodePorts = s.AllocateLoadBalancerNodePorts
+	service.Spec.ExternalTrafficPolicy = s.ExternalTrafficPolicy
+	service.Spec.HealthCheckNodePort = 0
+	for i := range service.Spec.Ports {
+		service.Spec.Ports[i].NodePort = 0
+	}
+
+	if withNodePorts {
+		service.Spec.HealthCheckNodePort = s.HealthCheckNodePort
+		for i, port := range service.Spec.Ports {
+			for _, recorded := range s.NodePorts {
+				if recorded.Port == port.Port && recorded.Protocol == port.Protocol {
+					service.Spec.Ports[i].NodePort = recorded.NodePort
+				}
+			}
+		}
+	}
+
+	if len(s.Annotations) > 0 && service.Annotations == nil {
+		service.Annotations = map[string]string{}
+	}
+	for key, value := range s.Annotations {
+		service.Annotations[key] = value
+	}
+}
+
+func (k8s K8Simpl) suspendLoadBalancer(namespace, name string, uid types.UID) func(context.Context) error {
+	return func(ctx context.Context) error {
+		service, err := k8s.clientset.CoreV1().Services(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if service.UID != uid {
+			return fmt.Errorf("Service %s in namespace %s was recreated while suspending", name, namespace)
+		}
+		if service.Spec.Type == corev1.ServiceTypeClusterIP {
+			slog.Debug("Service already switched to ClusterIP; skipping suspend", "namespace", namespace, "name", name)
+			return nil
+		}
+
+		toClusterIP(service)
+		_, err = k8s.clientset.CoreV1().Services(namespace).Update(ctx, service, metav1.UpdateOptions{})
+		if err != nil {
+			return err
+		}
+		slog.Info("Released load balancer of Service", "name", name, "namespace", namespace)
+		return nil
+	}
+}
+
+func (k8s K8Simpl) wakeLoadBalancer(ctx context.Context, namespace string, suspendable kubesleep.Suspendable) error {
+	var state serviceState
+	if err := json.Unmarshal(suspendable.State(), &state); err != nil {
+		return fmt.Errorf("invalid Service state: %w", err)
+	}
+
+	err := k8s.updateService(ctx, namespace, suspendable.Name(), state, true)
+	if apierrors.IsInvalid(err) && len(state.NodePorts) > 0 {
+		// The node ports may have been allocated by another Service while the namespace was asleep.
+		slog.Warn("Failed to restore the node ports of Service; allocating new ones", "name", suspendable.Name(), "namespace", namespace, "error", err)
+		err = k8s.updateService(ctx, namespace, suspendable.Name(), state, false)
+	}
+	if apierrors.IsNotFound(err) {
+		slog.Warn("Service was deleted while the namespace was suspended; skipping wake", "name", suspendable.Name(), "namespace", namespace)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	slog.Info("Restored load balancer of Service", "name", suspendable.Name(), "namespace", namespace)
+	return nil
+}
+
+func (k8s K8Simpl) updateService(ctx context.Context, namespace, name string, state serviceState, withNodePorts bool) error {
+	service, err := k8s.clientset.CoreV1().Services(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	state.restore(service, withNodePorts)
+	_, err = k8s.clientset.CoreV1().Services(namespace).Update(ctx, service, metav1.UpdateOptions{})
+	return err
+}
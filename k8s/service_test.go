@@ -0,0 +1,135 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func CreateLoadBalancer(ctx context.Context, k8s K8Simpl, namespace string, name string) (func() error, error) {
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   namespace,
+			Annotations: map[string]string{"service.beta.kubernetes.io/aws-load-balancer-internal": "true"},
+		},
+		Spec: corev1.ServiceSpec{
+			Type:                     corev1.ServiceTypeLoadBalancer,
+			Selector:                 map[string]string{"app": name},
+			LoadBalancerSourceRanges: []string{"10.0.0.0/8"},
+			ExternalTrafficPolicy:    corev1.ServiceExternalTrafficPolicyLocal,
+			Ports: []corev1.ServicePort{{
+				Name:       "http",
+				Port:       80,
+				Protocol:   corev1.ProtocolTCP,
+				TargetPort: intstr.FromInt32(8080),
+			}},
+		},
+	}
+
+	_, err := k8s.clientset.CoreV1().Services(namespace).Create(ctx, service, metav1.CreateOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	delete := func() error {
+		return k8s.clientset.CoreV1().Services(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+	}
+	return delete, nil
+}
+
+func (s *Integrationtest) TestLoadBalancers_DisabledByDefault() {
+	deleteNamespace, err := testNamespace(s.ctx, "load-balancers-disabled", s.k8s, false)
+	s.Require().NoError(err)
+	defer deleteNamespace()
+
+	delete, err := CreateLoadBalancer(s.ctx, *s.k8s, "load-balancers-disabled", "web")
+	s.Require().NoError(err)
+	defer delete()
+
+	suspendables, err := s.k8s.GetSuspendables(s.ctx, "load-balancers-disabled")
+	s.Require().NoError(err)
+	s.Require().NotContains(suspendables, "21:web")
+}
+
+func (s *Integrationtest) TestLoadBalancers_SuspendAndWake() {
+	deleteNamespace, err := testNamespace(s.ctx, "load-balancers-suspend", s.k8s, false)
+	s.Require().NoError(err)
+	defer deleteNamespace()
+
+	delete, err := CreateLoadBalancer(s.ctx, *s.k8s, "load-balancers-suspend", "web")
+	s.Require().NoError(err)
+	defer delete()
+
+	original, err := s.k8s.clientset.CoreV1().Services("load-balancers-suspend").Get(s.ctx, "web", metav1.GetOptions{})
+	s.Require().NoError(err)
+
+	k8s := *s.k8s
+	k8s.options.LoadBalancers = true
+
+	suspendables, err := k8s.GetSuspendables(s.ctx, "load-balancers-suspend")
+	s.Require().NoError(err)
+	before, ok := suspendables["21:web"]
+	s.Require().True(ok)
+	s.Require().Equal(int32(0), before.Replicas)
+	s.Require().NoError(before.Suspend(s.ctx))
+
+	suspended, err := s.k8s.clientset.CoreV1().Services("load-balancers-suspend").Get(s.ctx, "web", metav1.GetOptions{})
+	s.Require().NoError(err)
+	s.Require().Equal(corev1.ServiceTypeClusterIP, suspended.Spec.Type)
+	s.Require().Empty(suspended.Spec.LoadBalancerSourceRanges)
+	s.Require().Equal(int32(0), suspended.Spec.Ports[0].NodePort)
+
+	s.Require().NoError(k8s.ScaleSuspendable(s.ctx, "load-balancers-suspend", before))
+
+	actual, err := s.k8s.clientset.CoreV1().Services("load-balancers-suspend").Get(s.ctx, "web", metav1.GetOptions{})
+	s.Require().NoError(err)
+	s.Require().Equal(corev1.ServiceTypeLoadBalancer, actual.Spec.Type)
+	s.Require().Equal([]string{"10.0.0.0/8"}, actual.Spec.LoadBalancerSourceRanges)
+	s.Require().Equal(corev1.ServiceExternalTrafficPolicyLocal, actual.Spec.ExternalTrafficPolicy)
+	s.Require().Equal(original.Spec.Ports[0].NodePort, actual.Spec.Ports[0].NodePort)
+	s.Require().Equal(original.Spec.HealthCheckNodePort, actual.Spec.HealthCheckNodePort)
+	s.Require().Equal("true", actual.Annotations["service.beta.kubernetes.io/aws-load-balancer-internal"])
+}
+
+func (s *Unittest) TestServiceState_RoundTrip() {
+	class := "internal"
+	original := corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Annotations: map[string]string{"lb": "internal"}},
+		Spec: corev1.ServiceSpec{
+			Type:                     corev1.ServiceTypeLoadBalancer,
+			LoadBalancerSourceRanges: []string{"10.0.0.0/8"},
+			LoadBalancerClass:        &class,
+			ExternalTrafficPolicy:    corev1.ServiceExternalTrafficPolicyLocal,
+			HealthCheckNodePort:      32000,
+			Ports: []corev1.ServicePort{
+				{Name: "http", Port: 80, Protocol: corev1.ProtocolTCP, NodePort: 31080},
+				{Name: "dns", Port: 53, Protocol: corev1.ProtocolUDP, NodePort: 31053},
+			},
+		},
+	}
+
+	data, err := json.Marshal(newServiceState(original))
+	s.Require().NoError(err)
+	var state serviceState
+	s.Require().NoError(json.Unmarshal(data, &state))
+
+	service := *original.DeepCopy()
+	service.Annotations = nil
+	toClusterIP(&service)
+	s.Require().Equal(corev1.ServiceTypeClusterIP, service.Spec.Type)
+	s.Require().Nil(service.Spec.LoadBalancerClass)
+	s.Require().Equal(int32(0), service.Spec.Ports[1].NodePort)
+
+	state.restore(&service, true)
+	s.Require().Equal(original, service)
+
+	toClusterIP(&service)
+	state.restore(&service, false)
+	s.Require().Equal(corev1.ServiceTypeLoadBalancer, service.Spec.Type)
+	s.Require().Equal(int32(0), service.Spec.HealthCheckNodePort)
+	s.Require().Equal(int32(0), service.Spec.Ports[0].NodePort)
+}
@@ -60,8 +60,9 @@ func (k8s *K8Simpl) CreateStateFile(ctx context.Context, namespace string, data
 
 	configmap := &corev1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      STATE_FILE_NAME,
-			Namespace: namespace,
+			Name:        STATE_FILE_NAME,
+			Namespace:   namespace,
+			Annotations: argoCDStateFileAnnotations,
 		},
 		Data: data,
 	}
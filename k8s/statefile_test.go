@@ -4,6 +4,7 @@ import (
 	"log/slog"
 
 	kubesleep "github.com/Y0-L0/kubesleep/kubesleep"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 var TEST_SUSPENDABLES = map[string]kubesleep.Suspendable{
@@ -23,6 +24,10 @@ func (s *Integrationtest) TestCreateDeleteStatefile() {
 	_, err = s.k8s.CreateStateFile(s.ctx, "create-delete-statefile", map[string]string{})
 	s.Require().NoError(err)
 
+	configmap, err := s.k8s.clientset.CoreV1().ConfigMaps("create-delete-statefile").Get(s.ctx, STATE_FILE_NAME, metav1.GetOptions{})
+	s.Require().NoError(err)
+	s.Require().Equal("IgnoreExtraneous", configmap.Annotations["argocd.argoproj.io/compare-options"])
+
 	err = s.k8s.DeleteStateFile(s.ctx, "create-delete-statefile")
 	s.Require().NoError(err)
 }
@@ -6,6 +6,7 @@ import (
 
 	kubesleep "github.com/Y0-L0/kubesleep/kubesleep"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 )
 
 func (k8s K8Simpl) getStatefulSets(ctx context.Context, namespace string) (map[string]kubesleep.Suspendable, error) {
@@ -19,19 +20,32 @@ func (k8s K8Simpl) getStatefulSets(ctx context.Context, namespace string) (map[s
 	suspendables := map[string]kubesleep.Suspendable{}
 
 	for _, statefulSet := range statefulSets.Items {
+		if owner := metav1.GetControllerOf(&statefulSet); owner != nil {
+			s := k8s.operatorManagedSuspendable(namespace, "apps/v1", "StatefulSet", statefulSet.Name, *owner)
+			suspendables[s.Identifier()] = s
+			continue
+		}
+		// The API server defaults replicas to 1 when they are not set.
+		replicas := ptr.Deref(statefulSet.Spec.Replicas, 1)
 		var suspend func(context.Context) error
-		if statefulSet.Spec.Replicas != nil && *statefulSet.Spec.Replicas == 0 {
+		if replicas == 0 {
 			suspend = k8s.noopSuspendStatefulSet(namespace, statefulSet.Name)
 		} else {
 			suspend = k8s.suspendStatefulSet(namespace, statefulSet.Name)
 		}
 
-		s := kubesleep.NewSuspendable(
+		s, included, err := k8s.withDownscaler(namespace, STATEFULSETS_GVR, &statefulSet, kubesleep.NewSuspendable(
 			kubesleep.StatefulSet,
 			statefulSet.Name,
-			*statefulSet.Spec.Replicas,
+			replicas,
 			suspend,
-		)
+		))
+		if err != nil {
+			return nil, err
+		}
+		if !included {
+			continue
+		}
 		slog.Debug("parsed Suspendable", "Suspendable", s, "namespace", namespace)
 		suspendables[s.Identifier()] = s
 	}
@@ -11,13 +11,14 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
-func CreateStatefulSet(ctx context.Context, k8s K8Simpl, namespace string, name string, Replicas int32) (func() error, error) {
+func CreateStatefulSet(ctx context.Context, k8s K8Simpl, namespace string, name string, Replicas int32, owners ...metav1.OwnerReference) (func() error, error) {
 	labels := map[string]string{"app": name}
 
 	statefulset := &appsv1.StatefulSet{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      name,
-			Namespace: namespace,
+			Name:            name,
+			Namespace:       namespace,
+			OwnerReferences: owners,
 		},
 		Spec: appsv1.StatefulSetSpec{
 			Replicas: &Replicas,
@@ -137,7 +138,7 @@ func (s *Integrationtest) TestScaleStatefulSet() {
 	s.Require().NoError(err)
 	defer delete()
 
-	err = s.k8s.ScaleSuspendable(s.ctx, "scale-statefulsets", kubesleep.StatefulSet, "test-statefulset", int32(2))
+	err = s.k8s.ScaleSuspendable(s.ctx, "scale-statefulsets", kubesleep.NewSuspendable(kubesleep.StatefulSet, "test-statefulset", int32(2), nil))
 
 	actual := s.getSuspendable("scale-statefulsets", "1:test-statefulset")
 	s.Require().Equal(int32(2), actual.Replicas)
@@ -0,0 +1,276 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	kubesleep "github.com/Y0-L0/kubesleep/kubesleep"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+var (
+	KAFKA_GVK           = schema.GroupVersionKind{Group: "kafka.strimzi.io", Version: "v1beta2", Kind: "Kafka"}
+	KAFKA_NODE_POOL_GVK = schema.GroupVersionKind{Group: "kafka.strimzi.io", Version: "v1beta2", Kind: "KafkaNodePool"}
+)
+
+const (
+	STRIMZI_PAUSE_ANNOTATION                 = "strimzi.io/pause-reconciliation"
+	STRIMZI_SKIP_BROKER_SCALEDOWN_ANNOTATION = "strimzi.io/skip-broker-scaledown-check"
+	STRIMZI_CLUSTER_LABEL                    = "strimzi.io/cluster"
+)
+
+// kafkaState records the original pause annotation of a Kafka cluster and its original broker scale-down check annotation.
+type kafkaState struct {
+	annotationState
+	SkipBrokerScaleDownCheck *string `json:"skipBrokerScaleDownCheck,omitempty"`
+}
+
+// strimziGroups contain the Strimzi resources. Workloads they control are left to the operator.
+var strimziGroups = []string{"kafka.strimzi.io", "core.strimzi.io"}
+
+// getKafkaNodePools returns the KafkaNodePools of the namespace. They are scaled through their /scale subresource
+// by the Strimzi operator, which is paused only after the brokers are gone.
+func (k8s K8Simpl) getKafkaNodePools(ctx context.Context, namespace string) (map[string]kubesleep.Suspendable, error) {
+	mapping, err := k8s.mapper.RESTMapping(KAFKA_NODE_POOL_GVK.GroupKind(), KAFKA_NODE_POOL_GVK.Version)
+	if meta.IsNoMatchError(err) {
+		slog.Debug("Strimzi is not installed; skipping KafkaNodePools", "namespace", namespace)
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	nodePools, err := k8s.dynamic.Resource(mapping.Resource).
+		Namespace(namespace).
+		List(ctx, metav1.ListOptions{})
+	if apierrors.IsForbidden(err) {
+		slog.Warn("Missing permissions to list KafkaNodePools; skipping them", "namespace", namespace)
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	suspendables := map[string]kubesleep.Suspendable{}
+
+	for _, nodePool := range nodePools.Items {
+		replicas, _, err := unstructured.NestedInt64(nodePool.Object, "spec", "replicas")
+		if err != nil {
+			return nil, err
+		}
+
+		var suspend func(context.Context) error
+		if replicas == 0 {
+			suspend = k8s.noopSuspendKafkaNodePool(namespace, nodePool.GetName())
+		} else {
+			suspend = k8s.suspendKafkaNodePool(namespace, mapping.Resource.GroupResource(), nodePool.GetName(), nodePool.GetLabels()[STRIMZI_CLUSTER_LABEL])
+		}
+
+		s := kubesleep.NewSuspendable(
+			kubesleep.KafkaNodePool,
+			nodePool.GetName(),
+			int32(replicas),
+			suspend,
+		)
+		slog.Debug("parsed Suspendable", "Suspendable", s, "namespace", namespace)
+		suspendables[s.Identifier()] = s
+	}
+
+	return suspendables, nil
+}
+
+func (k8s K8Simpl) noopSuspendKafkaNodePool(namespace, name string) func(context.Context) error {
+	return func(ctx context.Context) error {
+		slog.Debug("KafkaNodePool already at 0 replicas; skipping suspend", "namespace", namespace, "name", name)
+		return nil
+	}
+}
+
+// suspendKafkaNodePool scales a node pool to zero. Strimzi refuses to scale down brokers that still hold partition replicas,
+// so the check is skipped on the Kafka cluster first. The original annotation is restored when the Kafka cluster is woken.
+func (k8s K8Simpl) suspendKafkaNodePool(namespace string, resource schema.GroupResource, name, cluster string) func(context.Context) error {
+	return func(ctx context.Context) error {
+		if cluster != "" {
+			if err := k8s.skipBrokerScaleDownCheck(ctx, namespace, cluster); err != nil {
+				return fmt.Errorf("failed to skip the broker scale-down check of Kafka cluster %s: %w", cluster, err)
+			}
+		}
+		if err := k8s.updateScale(ctx, namespace, resource, name, 0); err != nil {
+			return err
+		}
+		slog.Info("Suspended KafkaNodePool", "name", name, "namespace", namespace)
+		return nil
+	}
+}
+
+func (k8s K8Simpl) skipBrokerScaleDownCheck(ctx context.Context, namespace, cluster string) error {
+	mapping, err := k8s.mapper.RESTMapping(KAFKA_GVK.GroupKind(), KAFKA_GVK.Version)
+	if err != nil {
+		return err
+	}
+
+	skip := "true"
+	err = k8s.patchAnnotation(ctx, namespace, mapping.Resource, cluster, STRIMZI_SKIP_BROKER_SCALEDOWN_ANNOTATION, &skip)
+	if apierrors.IsNotFound(err) {
+		slog.Warn("Kafka cluster of KafkaNodePool not found; not skipping the broker scale-down check", "cluster", cluster, "namespace", namespace)
+		return nil
+	}
+	return err
+}
+
+func (k8s K8Simpl) scaleKafkaNodePool(ctx context.Context, namespace string, name string, replicas int32) error {
+	mapping, err := k8s.mapper.RESTMapping(KAFKA_NODE_POOL_GVK.GroupKind(), KAFKA_NODE_POOL_GVK.Version)
+	if err != nil {
+		return err
+	}
+
+	if err := k8s.updateScale(ctx, namespace, mapping.Resource.GroupResource(), name, replicas); err != nil {
+		return err
+	}
+	slog.Info("Woke up KafkaNodePool", "name", name, "namespace", namespace)
+	return nil
+}
+
+// getKafkas returns the Kafka clusters of the namespace. Their reconciliation is paused after their node pools
+// are scaled down and resumed before the node pools are scaled up again.
+func (k8s K8Simpl) getKafkas(ctx context.Context, namespace string) (map[string]kubesleep.Suspendable, error) {
+	mapping, err := k8s.mapper.RESTMapping(KAFKA_GVK.GroupKind(), KAFKA_GVK.Version)
+	if meta.IsNoMatchError(err) {
+		slog.Debug("Strimzi is not installed; skipping Kafka clusters", "namespace", namespace)
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	kafkas, err := k8s.dynamic.Resource(mapping.Resource).
+		Namespace(namespace).
+		List(ctx, metav1.ListOptions{})
+	if apierrors.IsForbidden(err) {
+		slog.Warn("Missing permissions to list Kafka clusters; skipping them", "namespace", namespace)
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	suspendables := map[string]kubesleep.Suspendable{}
+
+	for _, kafka := range kafkas.Items {
+		state := kafkaState{annotationState: annotationState{Annotation: STRIMZI_PAUSE_ANNOTATION}}
+		if value, found := kafka.GetAnnotations()[STRIMZI_PAUSE_ANNOTATION]; found {
+			state.Value = &value
+		}
+		if value, found := kafka.GetAnnotations()[STRIMZI_SKIP_BROKER_SCALEDOWN_ANNOTATION]; found {
+			state.SkipBrokerScaleDownCheck = &value
+		}
+		data, err := json.Marshal(state)
+		if err != nil {
+			return nil, err
+		}
+
+		var suspend func(context.Context) error
+		if state.Value != nil && *state.Value == "true" {
+			suspend = k8s.noopSuspendKafka(namespace, kafka.GetName())
+		} else {
+			suspend = k8s.suspendKafka(namespace, mapping.Resource, kafka.GetName())
+		}
+
+		s := kubesleep.NewSuspendable(
+			kubesleep.Kafka,
+			kafka.GetName(),
+			0,
+			suspend,
+		).WithState(data)
+		slog.Debug("parsed Suspendable", "Suspendable", s, "namespace", namespace)
+		suspendables[s.Identifier()] = s
+	}
+
+	return suspendables, nil
+}
+
+func (k8s K8Simpl) noopSuspendKafka(namespace, name string) func(context.Context) error {
+	return func(ctx context.Context) error {
+		slog.Debug("Kafka reconciliation already paused; skipping suspend", "namespace", namespace, "name", name)
+		return nil
+	}
+}
+
+func (k8s K8Simpl) suspendKafka(namespace string, resource schema.GroupVersionResource, name string) func(context.Context) error {
+	return func(ctx context.Context) error {
+		slog.Info("Waiting for the node pools of Kafka cluster to scale down", "name", name, "namespace", namespace)
+		if err := k8s.waitForKafkaNodePools(ctx, namespace, name); err != nil {
+			return fmt.Errorf("node pools of Kafka cluster %s did not scale down: %w", name, err)
+		}
+
+		paused := "true"
+		if err := k8s.patchAnnotation(ctx, namespace, resource, name, STRIMZI_PAUSE_ANNOTATION, &paused); err != nil {
+			return err
+		}
+		slog.Info("Paused reconciliation of Kafka cluster", "name", name, "namespace", namespace)
+		return nil
+	}
+}
+
+// waitForKafkaNodePools polls the node pools of a Kafka cluster until the operator reports that none of them runs a broker.
+func (k8s K8Simpl) waitForKafkaNodePools(ctx context.Context, namespace, cluster string) error {
+	mapping, err := k8s.mapper.RESTMapping(KAFKA_NODE_POOL_GVK.GroupKind(), KAFKA_NODE_POOL_GVK.Version)
+	if meta.IsNoMatchError(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	return wait.PollUntilContextTimeout(ctx, operatorPollInterval, operatorTimeout, true, func(ctx context.Context) (bool, error) {
+		nodePools, err := k8s.dynamic.Resource(mapping.Resource).Namespace(namespace).List(ctx, metav1.ListOptions{
+			LabelSelector: STRIMZI_CLUSTER_LABEL + "=" + cluster,
+		})
+		if err != nil {
+			return false, err
+		}
+		for _, nodePool := range nodePools.Items {
+			replicas, _, err := unstructured.NestedInt64(nodePool.Object, "status", "replicas")
+			if err != nil {
+				return false, err
+			}
+			if replicas > 0 {
+				return false, nil
+			}
+		}
+		return true, nil
+	})
+}
+
+func (k8s K8Simpl) wakeKafka(ctx context.Context, namespace string, suspendable kubesleep.Suspendable) error {
+	var state kafkaState
+	if err := json.Unmarshal(suspendable.State(), &state); err != nil {
+		return fmt.Errorf("invalid Kafka state: %w", err)
+	}
+
+	mapping, err := k8s.mapper.RESTMapping(KAFKA_GVK.GroupKind(), KAFKA_GVK.Version)
+	if err != nil {
+		return err
+	}
+
+	if err := k8s.patchAnnotation(ctx, namespace, mapping.Resource, suspendable.Name(), state.Annotation, state.Value); err != nil {
+		return err
+	}
+	if err := k8s.patchAnnotation(ctx, namespace, mapping.Resource, suspendable.Name(), STRIMZI_SKIP_BROKER_SCALEDOWN_ANNOTATION, state.SkipBrokerScaleDownCheck); err != nil {
+		return err
+	}
+	slog.Info("Resumed reconciliation of Kafka cluster", "name", suspendable.Name(), "namespace", namespace)
+	return nil
+}
+
+func isStrimziOwner(owner metav1.OwnerReference) bool {
+	return slices.Contains(strimziGroups, schema.FromAPIVersionAndKind(owner.APIVersion, owner.Kind).Group)
+}
@@ -0,0 +1,129 @@
+package k8s
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	KAFKA_KEY           = "17:test-kafka"
+	KAFKA_NODE_POOL_KEY = "16:brokers"
+)
+
+// createKafkaNodePool creates a node pool of the test-kafka cluster whose status reports the given replicas,
+// standing in for the operator that is not running in the test control plane.
+func (s *Integrationtest) createKafkaNodePool(namespace string, replicas, statusReplicas int64) func() error {
+	delete, err := CreateCustomResource(s.ctx, *s.k8s, KAFKA_NODE_POOL_GVR, "KafkaNodePool", namespace, "brokers", map[string]any{"replicas": replicas})
+	s.Require().NoError(err)
+
+	nodePool, err := s.k8s.dynamic.Resource(KAFKA_NODE_POOL_GVR).Namespace(namespace).Get(s.ctx, "brokers", metav1.GetOptions{})
+	s.Require().NoError(err)
+	nodePool.SetLabels(map[string]string{STRIMZI_CLUSTER_LABEL: "test-kafka"})
+	s.Require().NoError(unstructured.SetNestedField(nodePool.Object, statusReplicas, "status", "replicas"))
+	_, err = s.k8s.dynamic.Resource(KAFKA_NODE_POOL_GVR).Namespace(namespace).Update(s.ctx, nodePool, metav1.UpdateOptions{})
+	s.Require().NoError(err)
+	return delete
+}
+
+func (s *Integrationtest) TestKafkaNodePool_SuspendAndWake() {
+	deleteNamespace, err := testNamespace(s.ctx, "suspend-kafkanodepools", s.k8s, false)
+	s.Require().NoError(err)
+	defer deleteNamespace()
+	defer s.createKafkaNodePool("suspend-kafkanodepools", 3, 3)()
+
+	before := s.getSuspendable("suspend-kafkanodepools", KAFKA_NODE_POOL_KEY)
+	s.Require().Equal(int32(3), before.Replicas)
+	s.Require().NoError(before.Suspend(s.ctx))
+
+	suspended := s.getSuspendable("suspend-kafkanodepools", KAFKA_NODE_POOL_KEY)
+	s.Require().Equal(int32(0), suspended.Replicas)
+
+	s.Require().NoError(s.k8s.ScaleSuspendable(s.ctx, "suspend-kafkanodepools", before))
+
+	actual := s.getSuspendable("suspend-kafkanodepools", KAFKA_NODE_POOL_KEY)
+	s.Require().Equal(int32(3), actual.Replicas)
+}
+
+func (s *Integrationtest) TestKafka_SuspendAndWake() {
+	deleteNamespace, err := testNamespace(s.ctx, "suspend-kafkas", s.k8s, false)
+	s.Require().NoError(err)
+	defer deleteNamespace()
+	delete, err := CreateCustomResource(s.ctx, *s.k8s, KAFKA_GVR, "Kafka", "suspend-kafkas", "test-kafka", map[string]any{})
+	s.Require().NoError(err)
+	defer delete()
+	defer s.createKafkaNodePool("suspend-kafkas", 0, 0)()
+
+	before := s.getSuspendable("suspend-kafkas", KAFKA_KEY)
+	s.Require().NoError(before.Suspend(s.ctx))
+	s.Require().Equal("true", s.getAnnotations(KAFKA_GVR, "suspend-kafkas", "test-kafka")[STRIMZI_PAUSE_ANNOTATION])
+
+	s.Require().NoError(s.k8s.ScaleSuspendable(s.ctx, "suspend-kafkas", before))
+	s.Require().NotContains(s.getAnnotations(KAFKA_GVR, "suspend-kafkas", "test-kafka"), STRIMZI_PAUSE_ANNOTATION)
+}
+
+func (s *Integrationtest) TestKafka_SkipsBrokerScaleDownCheck() {
+	deleteNamespace, err := testNamespace(s.ctx, "skip-scaledown-kafkas", s.k8s, false)
+	s.Require().NoError(err)
+	defer deleteNamespace()
+	delete, err := CreateCustomResource(s.ctx, *s.k8s, KAFKA_GVR, "Kafka", "skip-scaledown-kafkas", "test-kafka", map[string]any{})
+	s.Require().NoError(err)
+	defer delete()
+	defer s.createKafkaNodePool("skip-scaledown-kafkas", 3, 0)()
+
+	kafka := s.getSuspendable("skip-scaledown-kafkas", KAFKA_KEY)
+	nodePool := s.getSuspendable("skip-scaledown-kafkas", KAFKA_NODE_POOL_KEY)
+	s.Require().NoError(nodePool.Suspend(s.ctx))
+	s.Require().Equal("true", s.getAnnotations(KAFKA_GVR, "skip-scaledown-kafkas", "test-kafka")[STRIMZI_SKIP_BROKER_SCALEDOWN_ANNOTATION])
+	s.Require().NoError(kafka.Suspend(s.ctx))
+
+	s.Require().NoError(s.k8s.ScaleSuspendable(s.ctx, "skip-scaledown-kafkas", kafka))
+	s.Require().NoError(s.k8s.ScaleSuspendable(s.ctx, "skip-scaledown-kafkas", nodePool))
+
+	annotations := s.getAnnotations(KAFKA_GVR, "skip-scaledown-kafkas", "test-kafka")
+	s.Require().NotContains(annotations, STRIMZI_PAUSE_ANNOTATION)
+	s.Require().NotContains(annotations, STRIMZI_SKIP_BROKER_SCALEDOWN_ANNOTATION)
+}
+
+func (s *Integrationtest) TestKafka_WaitsForNodePools() {
+	deleteNamespace, err := testNamespace(s.ctx, "wait-kafkas", s.k8s, false)
+	s.Require().NoError(err)
+	defer deleteNamespace()
+	delete, err := CreateCustomResource(s.ctx, *s.k8s, KAFKA_GVR, "Kafka", "wait-kafkas", "test-kafka", map[string]any{})
+	s.Require().NoError(err)
+	defer delete()
+	defer s.createKafkaNodePool("wait-kafkas", 0, 3)()
+
+	timeout := operatorTimeout
+	operatorTimeout = 100 * time.Millisecond
+	defer func() { operatorTimeout = timeout }()
+
+	before := s.getSuspendable("wait-kafkas", KAFKA_KEY)
+	err = before.Suspend(s.ctx)
+	s.Require().ErrorContains(err, "did not scale down")
+	s.Require().NotContains(s.getAnnotations(KAFKA_GVR, "wait-kafkas", "test-kafka"), STRIMZI_PAUSE_ANNOTATION)
+}
+
+func (s *Integrationtest) TestStatefulSet_SkipsStrimziOwned() {
+	deleteNamespace, err := testNamespace(s.ctx, "strimzi-statefulsets", s.k8s, false)
+	s.Require().NoError(err)
+	defer deleteNamespace()
+	delete, err := CreateStatefulSet(s.ctx, *s.k8s, "strimzi-statefulsets", "test-kafka-kafka", int32(3), metav1.OwnerReference{
+		APIVersion: "kafka.strimzi.io/v1beta2",
+		Kind:       "Kafka",
+		Name:       "test-kafka",
+		UID:        "5f1b9c2e-7a43-4a8e-9d61-3c2b1e0f4a7d",
+		Controller: ptr.To(true),
+	})
+	s.Require().NoError(err)
+	defer delete()
+
+	suspendables, err := s.k8s.GetSuspendables(s.ctx, "strimzi-statefulsets")
+	s.Require().NoError(err)
+	s.Require().NotContains(suspendables, "1:test-kafka-kafka")
+	owner := suspendables["20:StatefulSet.apps:test-kafka-kafka"].Owner()
+	s.Require().NotNil(owner)
+	s.Require().True(owner.Suspended)
+}
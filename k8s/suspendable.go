@@ -25,7 +25,7 @@ func mergeNoOverwrite[K comparable, V any](maps ...map[K]V) map[K]V {
 func (k8s K8Simpl) GetSuspendables(ctx context.Context, namespace string) (map[string]kubesleep.Suspendable, error) {
 	g, ctxGroup := errgroup.WithContext(ctx)
 
-	var deployments, statefulSets, cronJobs map[string]kubesleep.Suspendable
+	var deployments, statefulSets, rollouts, replicaSets, replicationControllers, daemonSets, cronJobs, jobs, barePods, scalables, fieldRules, annotated, argoApplications, fluxResources, cnpgClusters, virtualMachines, kafkaNodePools, kafkas, deploymentConfigs, cronWorkflows, loadBalancers, plugins map[string]kubesleep.Suspendable
 
 	g.Go(func() error {
 		var err error
@@ -37,27 +37,174 @@ func (k8s K8Simpl) GetSuspendables(ctx context.Context, namespace string) (map[s
 		statefulSets, err = k8s.getStatefulSets(ctxGroup, namespace)
 		return err
 	})
+	g.Go(func() error {
+		var err error
+		rollouts, err = k8s.getRollouts(ctxGroup, namespace)
+		return err
+	})
+	g.Go(func() error {
+		var err error
+		replicaSets, err = k8s.getReplicaSets(ctxGroup, namespace)
+		return err
+	})
+	g.Go(func() error {
+		var err error
+		replicationControllers, err = k8s.getReplicationControllers(ctxGroup, namespace)
+		return err
+	})
+	g.Go(func() error {
+		var err error
+		daemonSets, err = k8s.getDaemonSets(ctxGroup, namespace)
+		return err
+	})
 	g.Go(func() error {
 		var err error
 		cronJobs, err = k8s.getCronJobs(ctxGroup, namespace)
 		return err
 	})
+	g.Go(func() error {
+		var err error
+		jobs, err = k8s.getJobs(ctxGroup, namespace)
+		return err
+	})
+	g.Go(func() error {
+		var err error
+		barePods, err = k8s.getBarePods(ctxGroup, namespace)
+		return err
+	})
+	g.Go(func() error {
+		var err error
+		scalables, err = k8s.getScalables(ctxGroup, namespace)
+		return err
+	})
+	g.Go(func() error {
+		var err error
+		fieldRules, err = k8s.getFieldRuleSuspendables(ctxGroup, namespace)
+		return err
+	})
+	g.Go(func() error {
+		var err error
+		annotated, err = k8s.getAnnotationSuspendables(ctxGroup, namespace)
+		return err
+	})
+	g.Go(func() error {
+		var err error
+		argoApplications, err = k8s.getArgoApplications(ctxGroup, namespace)
+		return err
+	})
+	g.Go(func() error {
+		var err error
+		fluxResources, err = k8s.getFluxResources(ctxGroup, namespace)
+		return err
+	})
+	g.Go(func() error {
+		var err error
+		cnpgClusters, err = k8s.getCNPGClusters(ctxGroup, namespace)
+		return err
+	})
+	g.Go(func() error {
+		var err error
+		virtualMachines, err = k8s.getVirtualMachines(ctxGroup, namespace)
+		return err
+	})
+	g.Go(func() error {
+		var err error
+		kafkaNodePools, err = k8s.getKafkaNodePools(ctxGroup, namespace)
+		return err
+	})
+	g.Go(func() error {
+		var err error
+		kafkas, err = k8s.getKafkas(ctxGroup, namespace)
+		return err
+	})
+	g.Go(func() error {
+		var err error
+		deploymentConfigs, err = k8s.getDeploymentConfigs(ctxGroup, namespace)
+		return err
+	})
+	g.Go(func() error {
+		var err error
+		cronWorkflows, err = k8s.getCronWorkflows(ctxGroup, namespace)
+		return err
+	})
+	g.Go(func() error {
+		var err error
+		loadBalancers, err = k8s.getLoadBalancers(ctxGroup, namespace)
+		return err
+	})
+	g.Go(func() error {
+		var err error
+		plugins, err = k8s.getPluginSuspendables(ctxGroup, namespace)
+		return err
+	})
 	if err := g.Wait(); err != nil {
 		return nil, err
 	}
 
-	return mergeNoOverwrite(deployments, statefulSets, cronJobs), nil
+	suspendables := mergeNoOverwrite(deployments, statefulSets, rollouts, replicaSets, replicationControllers, daemonSets, cronJobs, jobs, barePods, scalables, fieldRules, annotated, argoApplications, fluxResources, cnpgClusters, virtualMachines, kafkaNodePools, kafkas, deploymentConfigs, cronWorkflows, loadBalancers, plugins)
+	if err := k8s.attachAutoscalers(ctx, namespace, suspendables); err != nil {
+		return nil, err
+	}
+	return suspendables, nil
 }
 
-func (k8s K8Simpl) ScaleSuspendable(ctx context.Context, namespace string, manifestType kubesleep.ManifestType, name string, replicas int32) error {
+func (k8s K8Simpl) ScaleSuspendable(ctx context.Context, namespace string, suspendable kubesleep.Suspendable) error {
+	manifestType, name, replicas := suspendable.ManifestType(), suspendable.Name(), suspendable.WakeReplicas()
 	slog.Debug("Scaling suspendable", "namespace", namespace, "name", name, "manifestType", manifestType, "replicas", replicas)
 	switch manifestType {
 	case kubesleep.Deplyoment:
-		return k8s.scaleDeployment(ctx, namespace, name, replicas)
+		if err := k8s.scaleDeployment(ctx, namespace, name, replicas); err != nil {
+			return err
+		}
+		return k8s.removeDownscalerAnnotation(ctx, namespace, DEPLOYMENTS_GVR, suspendable)
 	case kubesleep.StatefulSet:
-		return k8s.scaleStatefulSet(ctx, namespace, name, replicas)
+		if err := k8s.scaleStatefulSet(ctx, namespace, name, replicas); err != nil {
+			return err
+		}
+		return k8s.removeDownscalerAnnotation(ctx, namespace, STATEFULSETS_GVR, suspendable)
 	case kubesleep.CronJob:
-		return k8s.scaleCronJob(ctx, namespace, name, replicas)
+		return k8s.scaleCronJob(ctx, namespace, suspendable)
+	case kubesleep.Scalable:
+		return k8s.scaleScalable(ctx, namespace, suspendable)
+	case kubesleep.RuleBased:
+		return k8s.wakeFieldRule(ctx, namespace, suspendable)
+	case kubesleep.DaemonSet:
+		return k8s.wakeDaemonSet(ctx, namespace, suspendable)
+	case kubesleep.Job:
+		return k8s.scaleJob(ctx, namespace, name, suspendable.Suspended())
+	case kubesleep.ReplicaSet:
+		return k8s.scaleReplicaSet(ctx, namespace, name, replicas)
+	case kubesleep.ReplicationController:
+		return k8s.scaleReplicationController(ctx, namespace, name, replicas)
+	case kubesleep.BarePod:
+		return k8s.wakeBarePod(ctx, namespace, suspendable)
+	case kubesleep.Annotated:
+		return k8s.wakeAnnotation(ctx, namespace, suspendable)
+	case kubesleep.Rollout:
+		return k8s.scaleRollout(ctx, namespace, name, replicas)
+	case kubesleep.ArgoApplication:
+		return k8s.wakeArgoApplication(ctx, suspendable)
+	case kubesleep.FluxResource:
+		return k8s.wakeFluxResource(ctx, suspendable)
+	case kubesleep.PostgresCluster:
+		return k8s.wakeCNPGCluster(ctx, namespace, suspendable)
+	case kubesleep.VirtualMachine:
+		return k8s.wakeVirtualMachine(ctx, namespace, suspendable)
+	case kubesleep.KafkaNodePool:
+		return k8s.scaleKafkaNodePool(ctx, namespace, name, replicas)
+	case kubesleep.Kafka:
+		return k8s.wakeKafka(ctx, namespace, suspendable)
+	case kubesleep.DeploymentConfig:
+		return k8s.scaleDeploymentConfig(ctx, namespace, name, replicas)
+	case kubesleep.CronWorkflow:
+		return k8s.scaleCronWorkflow(ctx, namespace, suspendable)
+	case kubesleep.LoadBalancer:
+		return k8s.wakeLoadBalancer(ctx, namespace, suspendable)
+	case kubesleep.PluginBased:
+		return k8s.wakePluginResource(ctx, namespace, suspendable)
+	case kubesleep.OperatorManaged:
+		slog.Debug("Workload is managed by an operator; skipping wake", "namespace", namespace, "kind", suspendable.Kind(), "name", name)
+		return nil
 	default:
 		return fmt.Errorf("unknown manifest type: %d", manifestType)
 	}
@@ -0,0 +1,162 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"slices"
+	"strings"
+
+	kubesleep "github.com/Y0-L0/kubesleep/kubesleep"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/discovery"
+	"k8s.io/utils/ptr"
+)
+
+// suspendableGVK returns the kind of the object a suspendable refers to. Bare Pods are deleted on suspend,
+// Argo CD Applications and Flux resources usually live in another namespace, plugin resources are not necessarily
+// kubernetes objects and operator managed workloads are never touched, so they have no object to annotate.
+func suspendableGVK(suspendable kubesleep.Suspendable) (schema.GroupVersionKind, bool) {
+	switch suspendable.ManifestType() {
+	case kubesleep.Deplyoment:
+		return schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}, true
+	case kubesleep.StatefulSet:
+		return schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "StatefulSet"}, true
+	case kubesleep.DaemonSet:
+		return schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "DaemonSet"}, true
+	case kubesleep.ReplicaSet:
+		return schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "ReplicaSet"}, true
+	case kubesleep.CronJob:
+		return schema.GroupVersionKind{Group: "batch", Version: "v1", Kind: "CronJob"}, true
+	case kubesleep.Job:
+		return schema.GroupVersionKind{Group: "batch", Version: "v1", Kind: "Job"}, true
+	case kubesleep.ReplicationController:
+		return schema.GroupVersionKind{Version: "v1", Kind: "ReplicationController"}, true
+	case kubesleep.LoadBalancer:
+		return schema.GroupVersionKind{Version: "v1", Kind: "Service"}, true
+	case kubesleep.Rollout:
+		return ROLLOUT_GVK, true
+	case kubesleep.PostgresCluster:
+		return CNPG_CLUSTER_GVK, true
+	case kubesleep.VirtualMachine:
+		return VIRTUAL_MACHINE_GVK, true
+	case kubesleep.KafkaNodePool:
+		return KAFKA_NODE_POOL_GVK, true
+	case kubesleep.Kafka:
+		return KAFKA_GVK, true
+	case kubesleep.DeploymentConfig:
+		return DEPLOYMENT_CONFIG_GVK, true
+	case kubesleep.CronWorkflow:
+		return CRON_WORKFLOW_GVK, true
+	case kubesleep.Scalable, kubesleep.RuleBased, kubesleep.Annotated:
+		return schema.FromAPIVersionAndKind(suspendable.APIVersion(), suspendable.Kind()), true
+	default:
+		return schema.GroupVersionKind{}, false
+	}
+}
+
+func (k8s K8Simpl) suspendableResource(suspendable kubesleep.Suspendable) (schema.GroupVersionResource, bool, error) {
+	gvk, ok := suspendableGVK(suspendable)
+	if !ok {
+		return schema.GroupVersionResource{}, false, nil
+	}
+	mapping, err := k8s.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return schema.GroupVersionResource{}, false, err
+	}
+	return mapping.Resource, true, nil
+}
+
+func (k8s K8Simpl) AnnotateSuspendable(ctx context.Context, namespace string, suspendable kubesleep.Suspendable, annotations map[string]*string) error {
+	resource, ok, err := k8s.suspendableResource(suspendable)
+	// The annotations are only a fallback for a lost statefile, so an uninstalled kind must not fail suspend or wake.
+	if meta.IsNoMatchError(err) {
+		slog.Warn("Kind of suspended object is not installed; skipping its annotations", "suspendable", suspendable.Identifier(), "namespace", namespace, "error", err)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !ok {
+		slog.Debug("Suspendable has no object to annotate; skipping it", "namespace", namespace, "suspendable", suspendable.Identifier())
+		return nil
+	}
+
+	metadata := map[string]any{"annotations": annotations}
+	if record, ok := annotations[kubesleep.SUSPENDABLE_ANNOTATION]; ok {
+		var label *string
+		if record != nil {
+			label = ptr.To("true")
+		}
+		metadata["labels"] = map[string]*string{kubesleep.SUSPEND_RECORD_LABEL: label}
+	}
+	patch, err := json.Marshal(map[string]any{"metadata": metadata})
+	if err != nil {
+		return err
+	}
+
+	_, err = k8s.dynamic.Resource(resource).Namespace(namespace).Patch(ctx, suspendable.Name(), types.MergePatchType, patch, metav1.PatchOptions{})
+	if apierrors.IsNotFound(err) {
+		slog.Warn("Suspended object was deleted; skipping its annotations", "resource", resource, "name", suspendable.Name(), "namespace", namespace)
+		return nil
+	}
+	if apierrors.IsForbidden(err) {
+		slog.Warn("Missing permissions to annotate suspended object; it can't be woken without the statefile", "resource", resource, "name", suspendable.Name(), "namespace", namespace)
+		return nil
+	}
+	return err
+}
+
+// GetSuspendAnnotations looks for records on every object labelled as suspended in the namespace.
+// It searches all namespaced kinds instead of the suspendables, because wake doesn't know the field rules,
+// scale filters or options the namespace was suspended with.
+func (k8s K8Simpl) GetSuspendAnnotations(ctx context.Context, namespace string) ([]map[string]string, error) {
+	resourceLists, err := k8s.discovery.ServerPreferredNamespacedResources()
+	if discovery.IsGroupDiscoveryFailedError(err) {
+		slog.Warn("Failed to discover some api groups; their suspended objects won't be found", "error", err)
+	} else if err != nil {
+		return nil, err
+	}
+
+	var result []map[string]string
+	for _, resourceList := range resourceLists {
+		groupVersion, err := schema.ParseGroupVersion(resourceList.GroupVersion)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, resource := range resourceList.APIResources {
+			if strings.Contains(resource.Name, "/") || !slices.Contains(resource.Verbs, "list") {
+				continue
+			}
+			gvr := groupVersion.WithResource(resource.Name)
+
+			objects, err := k8s.dynamic.Resource(gvr).Namespace(namespace).List(ctx, metav1.ListOptions{
+				LabelSelector: kubesleep.SUSPEND_RECORD_LABEL,
+			})
+			if apierrors.IsForbidden(err) {
+				slog.Debug("Missing permissions to list resource; skipping it", "resource", gvr, "namespace", namespace)
+				continue
+			}
+			if apierrors.IsNotFound(err) || apierrors.IsMethodNotSupported(err) {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+
+			for _, object := range objects.Items {
+				if annotations := object.GetAnnotations(); annotations[kubesleep.SUSPENDABLE_ANNOTATION] != "" {
+					result = append(result, annotations)
+				}
+			}
+		}
+	}
+
+	slog.Debug("Found suspend annotations", "namespace", namespace, "count", len(result))
+	return result, nil
+}
@@ -0,0 +1,138 @@
+package k8s
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+
+	kubesleep "github.com/Y0-L0/kubesleep/kubesleep"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+)
+
+func (s *Integrationtest) TestSuspendAnnotations() {
+	deleteNamespace, err := testNamespace(s.ctx, "suspend-annotations", s.k8s, false)
+	s.Require().NoError(err)
+	defer deleteNamespace()
+
+	_, err = CreateDeployment(s.ctx, *s.k8s, "suspend-annotations", "web", 2)
+	s.Require().NoError(err)
+	_, err = CreateLoadBalancer(s.ctx, *s.k8s, "suspend-annotations", "public")
+	s.Require().NoError(err)
+
+	suspendables, err := s.k8s.GetSuspendables(s.ctx, "suspend-annotations")
+	s.Require().NoError(err)
+	web := suspendables["0:web"]
+	service := kubesleep.NewSuspendable(kubesleep.LoadBalancer, "public", 0, nil)
+	annotations := map[string]*string{
+		kubesleep.SUSPENDABLE_ANNOTATION: ptr.To(`{"ManifestType":0,"Name":"web","Replicas":2}`),
+		kubesleep.SUSPEND_RUN_ANNOTATION: ptr.To("run"),
+	}
+	s.Require().NoError(s.k8s.AnnotateSuspendable(s.ctx, "suspend-annotations", web, annotations))
+	s.Require().NoError(s.k8s.AnnotateSuspendable(s.ctx, "suspend-annotations", service, annotations))
+	s.Require().NoError(s.k8s.AnnotateSuspendable(s.ctx, "suspend-annotations", kubesleep.NewSuspendable(kubesleep.BarePod, "gone", 1, nil), annotations))
+	s.Require().NoError(s.k8s.AnnotateSuspendable(s.ctx, "suspend-annotations", kubesleep.NewSuspendable(kubesleep.Deplyoment, "deleted", 1, nil), annotations))
+
+	actual, err := s.k8s.GetSuspendAnnotations(s.ctx, "suspend-annotations")
+	s.Require().NoError(err)
+	s.Require().Len(actual, 2)
+	for _, annotation := range actual {
+		s.Require().Equal("run", annotation[kubesleep.SUSPEND_RUN_ANNOTATION])
+	}
+
+	removal := map[string]*string{kubesleep.SUSPENDABLE_ANNOTATION: nil, kubesleep.SUSPEND_RUN_ANNOTATION: nil}
+	s.Require().NoError(s.k8s.AnnotateSuspendable(s.ctx, "suspend-annotations", web, removal))
+
+	deployment, err := s.k8s.clientset.AppsV1().Deployments("suspend-annotations").Get(s.ctx, "web", metav1.GetOptions{})
+	s.Require().NoError(err)
+	s.Require().NotContains(deployment.Annotations, kubesleep.SUSPENDABLE_ANNOTATION)
+	s.Require().NotContains(deployment.Labels, kubesleep.SUSPEND_RECORD_LABEL)
+	actual, err = s.k8s.GetSuspendAnnotations(s.ctx, "suspend-annotations")
+	s.Require().NoError(err)
+	s.Require().Len(actual, 1)
+}
+
+// runCommand runs the kubesleep cli against the test cluster.
+func (s *Integrationtest) runCommand(args ...string) {
+	factory := func(options kubesleep.K8SOptions) (kubesleep.K8S, error) {
+		return newK8S(s.restconfig, options)
+	}
+	command, _ := kubesleep.NewParser(append([]string{"kubesleep"}, args...), factory, "", func(slog.Level) {})
+	command.SetOut(io.Discard)
+	s.Require().NoError(command.Execute())
+}
+
+func (s *Integrationtest) TestSuspendAnnotations_WakeFieldRuleWithoutStatefile() {
+	deleteNamespace, err := testNamespace(s.ctx, "field-rule-annotations", s.k8s, false)
+	s.Require().NoError(err)
+	defer deleteNamespace()
+
+	delete, err := CreateCustomResource(s.ctx, *s.k8s, GADGET_GVR, "Gadget", "field-rule-annotations", "test-gadget", map[string]any{
+		"nodePools": []any{map[string]any{"name": "a", "replicas": int64(3)}},
+	})
+	s.Require().NoError(err)
+	defer delete()
+	rulesFile := filepath.Join(s.T().TempDir(), "rules.yaml")
+	s.Require().NoError(os.WriteFile(rulesFile, []byte("rules:\n  - {apiVersion: test.kubesleep.xyz/v1, kind: Gadget, path: '.spec.nodePools[*].replicas', asleep: 0}\n"), 0o600))
+	replicas := func() any {
+		gadget, err := s.k8s.dynamic.Resource(GADGET_GVR).Namespace("field-rule-annotations").Get(s.ctx, "test-gadget", metav1.GetOptions{})
+		s.Require().NoError(err)
+		pools, _, _ := unstructured.NestedSlice(gadget.Object, "spec", "nodePools")
+		return pools[0].(map[string]any)["replicas"]
+	}
+
+	s.runCommand("suspend", "-n", "field-rule-annotations", "--rules", rulesFile)
+	s.Require().Equal(int64(0), replicas())
+	s.Require().NoError(s.k8s.clientset.CoreV1().ConfigMaps("field-rule-annotations").Delete(s.ctx, STATE_FILE_NAME, metav1.DeleteOptions{}))
+
+	s.runCommand("wake", "-n", "field-rule-annotations")
+
+	s.Require().Equal(int64(3), replicas())
+	gadget, err := s.k8s.dynamic.Resource(GADGET_GVR).Namespace("field-rule-annotations").Get(s.ctx, "test-gadget", metav1.GetOptions{})
+	s.Require().NoError(err)
+	s.Require().NotContains(gadget.GetAnnotations(), kubesleep.SUSPENDABLE_ANNOTATION)
+	s.Require().NotContains(gadget.GetLabels(), kubesleep.SUSPEND_RECORD_LABEL)
+}
+
+func (s *Unittest) TestSuspendableGVK() {
+	tests := []struct {
+		name        string
+		suspendable kubesleep.Suspendable
+		expected    schema.GroupVersionKind
+		ok          bool
+	}{
+		{"deployment", kubesleep.NewSuspendable(kubesleep.Deplyoment, "web", 1, nil), schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}, true},
+		{"cronjob", kubesleep.NewSuspendable(kubesleep.CronJob, "backup", 0, nil), schema.GroupVersionKind{Group: "batch", Version: "v1", Kind: "CronJob"}, true},
+		{"service", kubesleep.NewSuspendable(kubesleep.LoadBalancer, "public", 0, nil), schema.GroupVersionKind{Version: "v1", Kind: "Service"}, true},
+		{"kafka", kubesleep.NewSuspendable(kubesleep.Kafka, "kafka", 0, nil), KAFKA_GVK, true},
+		{"scalable", kubesleep.NewSuspendable(kubesleep.Scalable, "clones", 1, nil).WithResource("apps.kruise.io/v1alpha1", "CloneSet"), schema.GroupVersionKind{Group: "apps.kruise.io", Version: "v1alpha1", Kind: "CloneSet"}, true},
+		{"bare pod", kubesleep.NewSuspendable(kubesleep.BarePod, "debug", 1, nil), schema.GroupVersionKind{}, false},
+		{"argo application", kubesleep.NewSuspendable(kubesleep.ArgoApplication, "argocd/app", 0, nil), schema.GroupVersionKind{}, false},
+		{"plugin", kubesleep.NewSuspendable(kubesleep.PluginBased, "orders", 1, nil).WithResource("v1", "Queue"), schema.GroupVersionKind{}, false},
+		{"operator managed", kubesleep.NewSuspendable(kubesleep.OperatorManaged, "web", 0, nil).WithResource("apps/v1", "Deployment"), schema.GroupVersionKind{}, false},
+	}
+
+	for _, testCase := range tests {
+		s.Run(testCase.name, func() {
+			actual, ok := suspendableGVK(testCase.suspendable)
+			s.Require().Equal(testCase.ok, ok)
+			s.Require().Equal(testCase.expected, actual)
+		})
+	}
+}
+
+func (s *Unittest) TestAnnotateSuspendable_UninstalledKind() {
+	k8s := K8Simpl{mapper: meta.NewDefaultRESTMapper(nil)}
+	suspendable := kubesleep.NewSuspendable(kubesleep.Rollout, "web", 2, nil)
+
+	s.Require().NoError(k8s.AnnotateSuspendable(context.TODO(), "dev", suspendable, map[string]*string{kubesleep.SUSPENDABLE_ANNOTATION: nil}))
+}
+
+func (s *Unittest) TestSuspendRecordLabelIsDistinct() {
+	s.Require().NotEqual(SUSPENDED_NODE_SELECTOR, kubesleep.SUSPEND_RECORD_LABEL, "DaemonSet pod templates must not look like suspend records")
+}
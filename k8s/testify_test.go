@@ -11,24 +11,17 @@ import (
 	"github.com/stretchr/testify/suite"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"sigs.k8s.io/controller-runtime/pkg/envtest"
 )
 
-type Integrationtest struct {
+type LoggingSuite struct {
 	suite.Suite
 	logBuf    bytes.Buffer
 	oldLogger *slog.Logger
-
-	ctx         context.Context
-	cancel      context.CancelFunc
-	stopCluster func() error
-	k8s         *K8Simpl
-	restconfig  *rest.Config
 }
 
-func (s *Integrationtest) SetupTest() {
+func (s *LoggingSuite) SetupTest() {
 	s.logBuf.Reset()
 
 	handler := slog.NewTextHandler(&s.logBuf, &slog.HandlerOptions{
@@ -39,7 +32,7 @@ func (s *Integrationtest) SetupTest() {
 	slog.SetDefault(logger)
 }
 
-func (s *Integrationtest) TearDownTest() {
+func (s *LoggingSuite) TearDownTest() {
 	if !s.T().Failed() || !testing.Verbose() {
 		return
 	}
@@ -47,6 +40,24 @@ func (s *Integrationtest) TearDownTest() {
 	s.T().Log(s.logBuf.String())
 }
 
+type Unittest struct {
+	LoggingSuite
+}
+
+func TestUnit(t *testing.T) {
+	suite.Run(t, new(Unittest))
+}
+
+type Integrationtest struct {
+	LoggingSuite
+
+	ctx         context.Context
+	cancel      context.CancelFunc
+	stopCluster func() error
+	k8s         *K8Simpl
+	restconfig  *rest.Config
+}
+
 func (s *Integrationtest) SetupSuite() {
 	var err error
 	s.k8s, s.restconfig, s.stopCluster, err = testCluster()
@@ -60,16 +71,14 @@ func (s *Integrationtest) TearDownSuite() {
 }
 
 func testCluster() (*K8Simpl, *rest.Config, func() error, error) {
-	k8s := &K8Simpl{}
-
 	slog.Debug("Starting a testing kubernetes control plane")
-	testEnv := &envtest.Environment{}
+	testEnv := &envtest.Environment{CRDs: testCRDs}
 	cfg, err := testEnv.Start()
 	if err != nil {
 		return nil, nil, nil, fmt.Errorf("failed to start test cluster %w", err)
 	}
 
-	k8s.clientset, err = kubernetes.NewForConfig(cfg)
+	k8s, err := newK8S(cfg, kubesleep.K8SOptions{})
 	if err != nil {
 		return nil, nil, nil, fmt.Errorf("failed to create client config for the test cluster %w", err)
 	}
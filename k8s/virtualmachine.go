@@ -0,0 +1,165 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	kubesleep "github.com/Y0-L0/kubesleep/kubesleep"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var VIRTUAL_MACHINE_GVK = schema.GroupVersionKind{Group: "kubevirt.io", Version: "v1", Kind: "VirtualMachine"}
+
+const RUN_STRATEGY_HALTED = "Halted"
+
+// virtualMachineState records the run configuration of a VirtualMachine. KubeVirt accepts either
+// spec.runStrategy or the deprecated spec.running, a nil field did not exist.
+type virtualMachineState struct {
+	RunStrategy *string `json:"runStrategy,omitempty"`
+	Running     *bool   `json:"running,omitempty"`
+}
+
+func (s virtualMachineState) halted() bool {
+	if s.RunStrategy != nil {
+		return *s.RunStrategy == RUN_STRATEGY_HALTED
+	}
+	return s.Running == nil || !*s.Running
+}
+
+func (k8s K8Simpl) getVirtualMachines(ctx context.Context, namespace string) (map[string]kubesleep.Suspendable, error) {
+	if k8s.hasFieldRule(VIRTUAL_MACHINE_GVK.GroupKind()) {
+		slog.Debug("VirtualMachines are suspended by a field rule; skipping the built-in support", "namespace", namespace)
+		return nil, nil
+	}
+	mapping, err := k8s.mapper.RESTMapping(VIRTUAL_MACHINE_GVK.GroupKind(), VIRTUAL_MACHINE_GVK.Version)
+	if meta.IsNoMatchError(err) {
+		slog.Debug("KubeVirt is not installed; skipping VirtualMachines", "namespace", namespace)
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	virtualMachines, err := k8s.dynamic.Resource(mapping.Resource).
+		Namespace(namespace).
+		List(ctx, metav1.ListOptions{})
+	if apierrors.IsForbidden(err) {
+		slog.Warn("Missing permissions to list VirtualMachines; skipping them", "namespace", namespace)
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	suspendables := map[string]kubesleep.Suspendable{}
+
+	for _, virtualMachine := range virtualMachines.Items {
+		state, err := readVirtualMachineState(virtualMachine)
+		if err != nil {
+			return nil, err
+		}
+		data, err := json.Marshal(state)
+		if err != nil {
+			return nil, err
+		}
+
+		var suspend func(context.Context) error
+		var replicas int32
+		if state.halted() {
+			suspend = k8s.noopSuspendVirtualMachine(namespace, virtualMachine.GetName())
+		} else {
+			suspend = k8s.suspendVirtualMachine(namespace, mapping.Resource, virtualMachine.GetName())
+			replicas = 1
+		}
+
+		s := kubesleep.NewSuspendable(
+			kubesleep.VirtualMachine,
+			virtualMachine.GetName(),
+			replicas,
+			suspend,
+		).WithState(data)
+		slog.Debug("parsed Suspendable", "Suspendable", s, "namespace", namespace)
+		suspendables[s.Identifier()] = s
+	}
+
+	return suspendables, nil
+}
+
+func readVirtualMachineState(virtualMachine unstructured.Unstructured) (virtualMachineState, error) {
+	var state virtualMachineState
+	runStrategy, found, err := unstructured.NestedString(virtualMachine.Object, "spec", "runStrategy")
+	if err != nil {
+		return state, err
+	}
+	if found {
+		state.RunStrategy = &runStrategy
+	}
+	running, found, err := unstructured.NestedBool(virtualMachine.Object, "spec", "running")
+	if err != nil {
+		return state, err
+	}
+	if found {
+		state.Running = &running
+	}
+	return state, nil
+}
+
+func (k8s K8Simpl) noopSuspendVirtualMachine(namespace, name string) func(context.Context) error {
+	return func(ctx context.Context) error {
+		slog.Debug("VirtualMachine already halted; skipping suspend", "namespace", namespace, "name", name)
+		return nil
+	}
+}
+
+func (k8s K8Simpl) suspendVirtualMachine(namespace string, resource schema.GroupVersionResource, name string) func(context.Context) error {
+	return func(ctx context.Context) error {
+		halted := RUN_STRATEGY_HALTED
+		if err := k8s.patchVirtualMachine(ctx, namespace, resource, name, virtualMachineState{RunStrategy: &halted}); err != nil {
+			return err
+		}
+		slog.Info("Suspended VirtualMachine", "name", name, "namespace", namespace)
+		return nil
+	}
+}
+
+func (k8s K8Simpl) wakeVirtualMachine(ctx context.Context, namespace string, suspendable kubesleep.Suspendable) error {
+	var state virtualMachineState
+	if err := json.Unmarshal(suspendable.State(), &state); err != nil {
+		return fmt.Errorf("invalid VirtualMachine state: %w", err)
+	}
+
+	mapping, err := k8s.mapper.RESTMapping(VIRTUAL_MACHINE_GVK.GroupKind(), VIRTUAL_MACHINE_GVK.Version)
+	if err != nil {
+		return err
+	}
+
+	if err := k8s.patchVirtualMachine(ctx, namespace, mapping.Resource, suspendable.Name(), state); err != nil {
+		return err
+	}
+	slog.Info("Woke up VirtualMachine", "name", suspendable.Name(), "namespace", namespace)
+	return nil
+}
+
+// patchVirtualMachine sets both run fields at once because KubeVirt rejects VirtualMachines that set both.
+// A nil field is removed.
+func (k8s K8Simpl) patchVirtualMachine(ctx context.Context, namespace string, resource schema.GroupVersionResource, name string, state virtualMachineState) error {
+	patch, err := json.Marshal(map[string]any{
+		"spec": map[string]any{
+			"runStrategy": state.RunStrategy,
+			"running":     state.Running,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = k8s.dynamic.Resource(resource).Namespace(namespace).Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{})
+	return err
+}
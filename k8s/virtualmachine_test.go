@@ -0,0 +1,76 @@
+package k8s
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+const VIRTUAL_MACHINE_KEY = "15:test-vm"
+
+func (s *Integrationtest) getVirtualMachineSpec(namespace string) map[string]any {
+	virtualMachine, err := s.k8s.dynamic.Resource(VIRTUAL_MACHINE_GVR).Namespace(namespace).Get(s.ctx, "test-vm", metav1.GetOptions{})
+	s.Require().NoError(err)
+	return virtualMachine.Object["spec"].(map[string]any)
+}
+
+func (s *Integrationtest) TestVirtualMachine_Get() {
+	deleteNamespace, err := testNamespace(s.ctx, "get-vms", s.k8s, false)
+	s.Require().NoError(err)
+	defer deleteNamespace()
+	deleteVM, err := CreateCustomResource(s.ctx, *s.k8s, VIRTUAL_MACHINE_GVR, "VirtualMachine", "get-vms", "test-vm", map[string]any{"runStrategy": "Always"})
+	s.Require().NoError(err)
+	defer deleteVM()
+	deleteHalted, err := CreateCustomResource(s.ctx, *s.k8s, VIRTUAL_MACHINE_GVR, "VirtualMachine", "get-vms", "halted-vm", map[string]any{"runStrategy": "Halted"})
+	s.Require().NoError(err)
+	defer deleteHalted()
+
+	suspendables, err := s.k8s.GetSuspendables(s.ctx, "get-vms")
+	s.Require().NoError(err)
+	s.Require().Equal(int32(1), suspendables[VIRTUAL_MACHINE_KEY].Replicas)
+	s.Require().Equal(int32(0), suspendables["15:halted-vm"].Replicas)
+}
+
+func (s *Integrationtest) TestVirtualMachine_SuspendAndWakeRunStrategy() {
+	deleteNamespace, err := testNamespace(s.ctx, "suspend-vm-strategy", s.k8s, false)
+	s.Require().NoError(err)
+	defer deleteNamespace()
+	deleteVM, err := CreateCustomResource(s.ctx, *s.k8s, VIRTUAL_MACHINE_GVR, "VirtualMachine", "suspend-vm-strategy", "test-vm", map[string]any{"runStrategy": "RerunOnFailure"})
+	s.Require().NoError(err)
+	defer deleteVM()
+
+	before := s.getSuspendable("suspend-vm-strategy", VIRTUAL_MACHINE_KEY)
+	s.Require().NoError(before.Suspend(s.ctx))
+	s.Require().Equal("Halted", s.getVirtualMachineSpec("suspend-vm-strategy")["runStrategy"])
+
+	s.Require().NoError(s.k8s.ScaleSuspendable(s.ctx, "suspend-vm-strategy", before))
+	s.Require().Equal("RerunOnFailure", s.getVirtualMachineSpec("suspend-vm-strategy")["runStrategy"])
+}
+
+func (s *Integrationtest) TestVirtualMachine_SuspendAndWakeRunning() {
+	deleteNamespace, err := testNamespace(s.ctx, "suspend-vm-running", s.k8s, false)
+	s.Require().NoError(err)
+	defer deleteNamespace()
+	deleteVM, err := CreateCustomResource(s.ctx, *s.k8s, VIRTUAL_MACHINE_GVR, "VirtualMachine", "suspend-vm-running", "test-vm", map[string]any{"running": true})
+	s.Require().NoError(err)
+	defer deleteVM()
+
+	before := s.getSuspendable("suspend-vm-running", VIRTUAL_MACHINE_KEY)
+	s.Require().NoError(before.Suspend(s.ctx))
+	suspended := s.getVirtualMachineSpec("suspend-vm-running")
+	s.Require().Equal("Halted", suspended["runStrategy"])
+	s.Require().NotContains(suspended, "running")
+
+	s.Require().NoError(s.k8s.ScaleSuspendable(s.ctx, "suspend-vm-running", before))
+	actual := s.getVirtualMachineSpec("suspend-vm-running")
+	s.Require().Equal(true, actual["running"])
+	s.Require().NotContains(actual, "runStrategy")
+}
+
+func (s *Unittest) TestVirtualMachineStateHalted() {
+	s.True(virtualMachineState{}.halted())
+	s.True(virtualMachineState{RunStrategy: ptr.To("Halted")}.halted())
+	s.True(virtualMachineState{Running: ptr.To(false)}.halted())
+	s.False(virtualMachineState{Running: ptr.To(true)}.halted())
+	s.False(virtualMachineState{RunStrategy: ptr.To("Manual")}.halted())
+	s.False(virtualMachineState{RunStrategy: ptr.To("Always")}.halted())
+}
@@ -35,6 +35,13 @@ func validateAllNamespaces(config *cliConfig) error {
 	return validateNamespaces(config.namespaces)
 }
 
+func validateKinds(kinds []string) error {
+	if slices.Contains(kinds, "") {
+		return CliArgumentError("Invalid kind value.\nkinds must use the Kind.version.group or Kind.group notation.")
+	}
+	return nil
+}
+
 func validateNamespaces(namespaces []string) error {
 	if slices.Contains(namespaces, "") {
 		return CliArgumentError("Invalid namespace value")
@@ -42,7 +49,9 @@ func validateNamespaces(namespaces []string) error {
 	return nil
 }
 
-func NewParser(args []string, k8sFactory func() (K8S, error), setupLogging func(slog.Level)) (*cobra.Command, *cliConfig) {
+// NewParser builds the kubesleep command. pluginPath is the PATH style list of directories searched for plugins
+// by the commands suspending or waking namespaces.
+func NewParser(args []string, k8sFactory K8SFactory, pluginPath string, setupLogging func(slog.Level)) (*cobra.Command, *cliConfig) {
 	slog.Debug("raw cli arguments", "args", args)
 
 	config := &cliConfig{}
@@ -100,6 +109,13 @@ func NewParser(args []string, k8sFactory func() (K8S, error), setupLogging func(
 			if err := validateAllNamespaces(config); err != nil {
 				return err
 			}
+			if err := validateKinds(append(config.scaleAllow, config.scaleDeny...)); err != nil {
+				return err
+			}
+			if _, err := ParseActiveJobPolicy(config.activeJobs); err != nil {
+				return err
+			}
+			config.pluginPath = pluginPath
 			return config.suspend(cmd.Context(), k8sFactory)
 		},
 	}
@@ -116,6 +132,75 @@ func NewParser(args []string, k8sFactory func() (K8S, error), setupLogging func(
 		false,
 		"Suspend all unprotected namespaces",
 	)
+	suspendCmd.Flags().StringArrayVar(
+		&config.scaleAllow,
+		"scale-allow",
+		nil,
+		"Only suspend these kinds through their /scale subresource (e.g. CloneSet.apps.kruise.io). Can be specified multiple times",
+	)
+	suspendCmd.Flags().StringArrayVar(
+		&config.scaleDeny,
+		"scale-deny",
+		nil,
+		"Never suspend these kinds through their /scale subresource. Can be specified multiple times",
+	)
+	suspendCmd.Flags().StringVar(
+		&config.rulesFile,
+		"rules",
+		"",
+		"Path to a YAML file with field based suspension rules for custom resources",
+	)
+	suspendCmd.Flags().StringVar(
+		&config.activeJobs,
+		"active-jobs",
+		"",
+		"What to do with running Jobs of suspended CronJobs and Workflows of suspended CronWorkflows: keep, suspend or terminate (default keep)",
+	)
+	suspendCmd.Flags().BoolVar(
+		&config.barePods,
+		"bare-pods",
+		false,
+		"Also suspend Pods without an owner by deleting them and recreating them on wake",
+	)
+	suspendCmd.Flags().BoolVar(
+		&config.discardEmpty,
+		"discard-emptydir",
+		false,
+		"Suspend bare Pods with emptyDir volumes even though their contents are lost",
+	)
+	suspendCmd.Flags().BoolVar(
+		&config.loadBalancers,
+		"load-balancers",
+		false,
+		"Also switch Services of type LoadBalancer to ClusterIP to release their load balancers",
+	)
+	suspendCmd.Flags().BoolVar(
+		&config.downscalerAnnotations,
+		"downscaler-annotations",
+		false,
+		"Record the original replicas of Deployments and StatefulSets in the kube-downscaler annotation downscaler/original-replicas",
+	)
+
+	importCmd := &cobra.Command{
+		Use:   "import",
+		Short: "Suspend namespaces scaled down by kube-downscaler, keeping the replicas it recorded",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			slog.Debug("Parsed cli arguments for the import subcommand", "config", config)
+			config.importDownscaler = true
+			config.pluginPath = pluginPath
+			if err := validateAllNamespaces(config); err != nil {
+				return err
+			}
+			if err := validateKinds(append(config.scaleAllow, config.scaleDeny...)); err != nil {
+				return err
+			}
+			if _, err := ParseActiveJobPolicy(config.activeJobs); err != nil {
+				return err
+			}
+			return config.suspend(cmd.Context(), k8sFactory)
+		},
+	}
+	importCmd.Flags().AddFlagSet(suspendCmd.Flags())
 
 	wakeCmd := &cobra.Command{
 		Use:   "wake",
@@ -128,9 +213,16 @@ func NewParser(args []string, k8sFactory func() (K8S, error), setupLogging func(
 			if len(config.namespaces) == 0 {
 				return CliArgumentError("Missing namespace argument.\n--namespace (-n) must be specified.")
 			}
+			config.pluginPath = pluginPath
 			return config.wake(cmd.Context(), k8sFactory)
 		},
 	}
+	wakeCmd.Flags().BoolVar(
+		&config.skipMissed,
+		"skip-missed-runs",
+		false,
+		"Prevent CronJobs from catching up on runs missed while the namespace was suspended",
+	)
 
 	statusCmd := &cobra.Command{
 		Use:   "status",
@@ -150,6 +242,28 @@ func NewParser(args []string, k8sFactory func() (K8S, error), setupLogging func(
 		"Suspend all unprotected namespaces",
 	)
 
-	rootCmd.AddCommand(versionCmd, suspendCmd, wakeCmd, statusCmd)
+	recoverCmd := &cobra.Command{
+		Use:   "recover",
+		Short: "Reconstruct a lost suspend state from Helm releases, last-applied-configuration and managedFields",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			slog.Debug("Parsed cli arguments for the recover subcommand", "config", config)
+			if err := validateNamespaces(config.namespaces); err != nil {
+				return err
+			}
+			if len(config.namespaces) == 0 {
+				return CliArgumentError("Missing namespace argument.\n--namespace (-n) must be specified.")
+			}
+			return config.recover(cmd.Context(), k8sFactory, cmd.InOrStdin())
+		},
+	}
+	recoverCmd.Flags().BoolVarP(
+		&config.yes,
+		"yes",
+		"y",
+		false,
+		"Write the recovered suspend state without asking for confirmation",
+	)
+
+	rootCmd.AddCommand(versionCmd, suspendCmd, importCmd, wakeCmd, statusCmd, recoverCmd)
 	return rootCmd, config
 }
@@ -7,7 +7,7 @@ import (
 )
 
 func NewTestParser(args []string, k8sFactory K8SFactory) (*cliConfig, error) {
-	command, config := NewParser(args, k8sFactory, SetupLogging)
+	command, config := NewParser(args, k8sFactory, "", SetupLogging)
 	err := command.Execute()
 	return config, err
 }
@@ -59,12 +59,66 @@ func (s *Unittest) TestValidCliArguments() {
 			"suspend",
 			&cliConfig{namespaces: []string{"test-ns"}, force: true, allNamespaces: false},
 		},
+		{
+			"suspend with scale allow and deny lists",
+			[]string{"kubesleep", "suspend", "-n", "test-ns", "--scale-allow", "CloneSet.apps.kruise.io", "--scale-deny", "Prometheus.monitoring.coreos.com"},
+			"suspend",
+			&cliConfig{namespaces: []string{"test-ns"}, scaleAllow: []string{"CloneSet.apps.kruise.io"}, scaleDeny: []string{"Prometheus.monitoring.coreos.com"}},
+		},
+		{
+			"suspend with active jobs policy",
+			[]string{"kubesleep", "suspend", "-n", "test-ns", "--active-jobs", "terminate"},
+			"suspend",
+			&cliConfig{namespaces: []string{"test-ns"}, activeJobs: "terminate"},
+		},
+		{
+			"suspend bare pods",
+			[]string{"kubesleep", "suspend", "-n", "test-ns", "--bare-pods", "--discard-emptydir"},
+			"suspend",
+			&cliConfig{namespaces: []string{"test-ns"}, barePods: true, discardEmpty: true},
+		},
+		{
+			"suspend with downscaler annotations",
+			[]string{"kubesleep", "suspend", "-n", "test-ns", "--downscaler-annotations"},
+			"suspend",
+			&cliConfig{namespaces: []string{"test-ns"}, downscalerAnnotations: true},
+		},
+		{
+			"import from kube-downscaler",
+			[]string{"kubesleep", "import", "-n", "test-ns", "--downscaler-annotations"},
+			"import",
+			&cliConfig{namespaces: []string{"test-ns"}, importDownscaler: true, downscalerAnnotations: true},
+		},
+		{
+			"import all namespaces",
+			[]string{"kubesleep", "import", "--all-namespaces"},
+			"import",
+			&cliConfig{allNamespaces: true, importDownscaler: true},
+		},
+		{
+			"recover without confirmation",
+			[]string{"kubesleep", "recover", "-n", "test-ns", "-y"},
+			"recover",
+			&cliConfig{namespaces: []string{"test-ns"}, yes: true},
+		},
+		{
+			"suspend load balancers",
+			[]string{"kubesleep", "suspend", "-n", "test-ns", "--load-balancers"},
+			"suspend",
+			&cliConfig{namespaces: []string{"test-ns"}, loadBalancers: true},
+		},
 		{
 			"wake with ns",
 			[]string{"kubesleep", "wake", "-n", "test-ns"},
 			"wake",
 			&cliConfig{namespaces: []string{"test-ns"}, force: false, allNamespaces: false},
 		},
+		{
+			"wake skipping missed runs",
+			[]string{"kubesleep", "wake", "-n", "test-ns", "--skip-missed-runs"},
+			"wake",
+			&cliConfig{namespaces: []string{"test-ns"}, skipMissed: true},
+		},
 		{
 			"status with ns",
 			[]string{"kubesleep", "status", "-n", "test-ns"},
@@ -97,6 +151,7 @@ func (s *Unittest) TestValidCliArguments() {
 			command, config := NewParser(
 				testCase.args,
 				factory,
+				"",
 				SetupLogging,
 			)
 			err := command.Execute()
@@ -134,6 +189,7 @@ func (s *Unittest) TestVersionSubcommand() {
 			command, config := NewParser(
 				testCase.args,
 				nil,
+				"",
 				SetupLogging,
 			)
 			err := command.Execute()
@@ -159,6 +215,10 @@ func (s *Unittest) TestInvalidCliArguments() {
 		{"suspend no namespace force", []string{"kubesleep", "suspend", "--force"}, &cliConfig{force: true}},
 		{"suspend all namespaces force", []string{"kubesleep", "suspend", "--all-namespaces", "--force"}, &cliConfig{allNamespaces: true, force: true}},
 		{"suspend all namespaces namespace colision", []string{"kubesleep", "suspend", "--all-namespaces", "--namespace", "foo"}, &cliConfig{allNamespaces: true, namespaces: []string{"foo"}}},
+		{"suspend empty scale allow kind", []string{"kubesleep", "suspend", "-n", "foo", "--scale-allow", ""}, &cliConfig{namespaces: []string{"foo"}, scaleAllow: []string{""}}},
+		{"suspend invalid active jobs policy", []string{"kubesleep", "suspend", "-n", "foo", "--active-jobs", "pause"}, &cliConfig{namespaces: []string{"foo"}, activeJobs: "pause"}},
+		{"import no namespace", []string{"kubesleep", "import"}, &cliConfig{importDownscaler: true}},
+		{"recover no namespace", []string{"kubesleep", "recover"}, &cliConfig{}},
 		{"status no namespace", []string{"kubesleep", "status"}, &cliConfig{}},
 		{"status empty namespace", []string{"kubesleep", "status", "-n", ""}, &cliConfig{namespaces: []string{""}}},
 		{"status all namespaces namespace colision", []string{"kubesleep", "status", "--all-namespaces", "--namespace", "foo"}, &cliConfig{allNamespaces: true, namespaces: []string{"foo"}}},
@@ -167,7 +227,7 @@ func (s *Unittest) TestInvalidCliArguments() {
 
 	for _, testCase := range tests {
 		s.Run(testCase.name, func() {
-			command, config := NewParser(testCase.args, nil, SetupLogging)
+			command, config := NewParser(testCase.args, nil, "", SetupLogging)
 
 			err := command.Execute()
 
@@ -204,7 +264,7 @@ func (s *Unittest) TestLogLevel() {
 
 			mockSetupLogging := func(l slog.Level) { logLevel = l }
 
-			command, config := NewParser(testCase.args, factory, mockSetupLogging)
+			command, config := NewParser(testCase.args, factory, "", mockSetupLogging)
 			err := command.Execute()
 
 			s.Require().Equal(errExpected, err)
@@ -1,22 +1,71 @@
 package kubesleep
 
 import (
+	"bufio"
 	"context"
 	"errors"
 	"fmt"
 	"io"
 	"log/slog"
 	"slices"
+	"strings"
 	"text/tabwriter"
 
 	"golang.org/x/sync/errgroup"
 )
 
 type cliConfig struct {
-	namespaces    []string
-	force         bool
-	allNamespaces bool
-	outWriter     io.Writer
+	namespaces            []string
+	force                 bool
+	allNamespaces         bool
+	scaleAllow            []string
+	scaleDeny             []string
+	rulesFile             string
+	activeJobs            string
+	skipMissed            bool
+	barePods              bool
+	discardEmpty          bool
+	loadBalancers         bool
+	importDownscaler      bool
+	downscalerAnnotations bool
+	yes                   bool
+	pluginPath            string
+	outWriter             io.Writer
+}
+
+func (c cliConfig) k8sOptions() (K8SOptions, error) {
+	var err error
+	options := K8SOptions{
+		ScaleAllow:            c.scaleAllow,
+		ScaleDeny:             c.scaleDeny,
+		SkipMissedRuns:        c.skipMissed,
+		BarePods:              c.barePods,
+		DiscardEmptyDir:       c.discardEmpty,
+		LoadBalancers:         c.loadBalancers,
+		DownscalerImport:      c.importDownscaler,
+		DownscalerAnnotations: c.downscalerAnnotations,
+	}
+	if c.pluginPath != "" {
+		options.Plugins = DiscoverPlugins(c.pluginPath)
+	}
+	options.ActiveJobs, err = ParseActiveJobPolicy(c.activeJobs)
+	if err != nil {
+		return options, err
+	}
+	if c.rulesFile == "" {
+		return options, nil
+	}
+
+	options.FieldRules, err = LoadFieldRules(c.rulesFile)
+	return options, err
+}
+
+func (c cliConfig) newK8S(k8sFactory K8SFactory) (K8S, error) {
+	options, err := c.k8sOptions()
+	if err != nil {
+		return nil, err
+	}
+	return k8sFactory(options)
 }
 
 func (c cliConfig) validate() {
@@ -58,10 +107,10 @@ func (c cliConfig) getNamespaces(ctx context.Context, k8s K8S) ([]SuspendableNam
 	return namespaces, nil
 }
 
-func (c cliConfig) suspend(ctx context.Context, k8sFactory func() (K8S, error)) error {
+func (c cliConfig) suspend(ctx context.Context, k8sFactory K8SFactory) error {
 	c.validate()
 
-	k8s, err := k8sFactory()
+	k8s, err := c.newK8S(k8sFactory)
 	if err != nil {
 		return err
 	}
@@ -92,9 +141,9 @@ func (c cliConfig) suspend(ctx context.Context, k8sFactory func() (K8S, error))
 	return nil
 }
 
-func (c cliConfig) wake(ctx context.Context, k8sFactory func() (K8S, error)) error {
+func (c cliConfig) wake(ctx context.Context, k8sFactory K8SFactory) error {
 	c.validate()
-	k8s, err := k8sFactory()
+	k8s, err := c.newK8S(k8sFactory)
 	if err != nil {
 		return err
 	}
@@ -113,16 +162,79 @@ func (c cliConfig) wake(ctx context.Context, k8sFactory func() (K8S, error)) err
 	return nil
 }
 
+func (c cliConfig) recover(ctx context.Context, k8sFactory K8SFactory, in io.Reader) error {
+	c.validate()
+	k8s, err := c.newK8S(k8sFactory)
+	if err != nil {
+		return err
+	}
+
+	namespaces, err := c.getNamespaces(ctx, k8s)
+	if err != nil {
+		return err
+	}
+	answers := bufio.NewScanner(in)
+	for _, ns := range namespaces {
+		recovered, err := ns.recoverState(ctx, k8s)
+		if err != nil {
+			return err
+		}
+		if !slices.ContainsFunc(recovered, func(r RecoveredSuspendable) bool { return r.Source != "" }) {
+			fmt.Fprintf(c.outWriter, "Nothing to recover in namespace %s\n", ns.Name())
+			continue
+		}
+
+		c.printRecovered(ns.Name(), recovered)
+		if !c.yes {
+			fmt.Fprintf(c.outWriter, "Write this suspend state for namespace %s? [y/N] ", ns.Name())
+			if !answers.Scan() || !slices.Contains([]string{"y", "yes"}, strings.ToLower(strings.TrimSpace(answers.Text()))) {
+				fmt.Fprintf(c.outWriter, "Skipped namespace %s\n", ns.Name())
+				continue
+			}
+		}
+
+		if err := ns.writeRecoveredState(ctx, k8s, recovered); err != nil {
+			return err
+		}
+		fmt.Fprintf(c.outWriter, "Recovered namespace %s. Run kubesleep wake -n %s to wake it\n", ns.Name(), ns.Name())
+	}
+	return nil
+}
+
+func (c cliConfig) printRecovered(namespace string, recovered []RecoveredSuspendable) {
+	fmt.Fprintf(c.outWriter, "Recovered suspend state of namespace %s:\n", namespace)
+	w := tabwriter.NewWriter(c.outWriter, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "kind\tname\twakesWith\tsource\t")
+	for _, r := range recovered {
+		sus := r.Suspendable
+		wakesWith := fmt.Sprintf("%d replicas", sus.Replicas)
+		if sus.manifestType.hasSuspendFlag() {
+			wakesWith = "running"
+			if sus.Suspended() {
+				wakesWith = "suspended"
+			}
+		}
+		source := r.Source
+		if source == "" {
+			source = "not recovered, stays at 0"
+			wakesWith = "-"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t\n", sus.displayKind(), sus.name, wakesWith, source)
+	}
+	w.Flush()
+}
+
 type status struct {
 	name      string
 	status    string
 	protected bool
 	suspended int32
+	notes     []string
 }
 
-func (c cliConfig) status(ctx context.Context, k8sFactory func() (K8S, error)) error {
+func (c cliConfig) status(ctx context.Context, k8sFactory K8SFactory) error {
 	c.validate()
-	k8s, err := k8sFactory()
+	k8s, err := c.newK8S(k8sFactory)
 	if err != nil {
 		return err
 	}
@@ -136,7 +248,7 @@ func (c cliConfig) status(ctx context.Context, k8sFactory func() (K8S, error)) e
 
 	for i, namespace := range namespaces {
 		g.Go(func() error {
-			statusString, suspended, err := namespace.status(ctxGroup, k8s)
+			statusString, stateFile, err := namespace.status(ctxGroup, k8s)
 			if err != nil {
 				return err
 			}
@@ -144,7 +256,10 @@ func (c cliConfig) status(ctx context.Context, k8sFactory func() (K8S, error)) e
 				name:      namespace.Name(),
 				status:    statusString,
 				protected: namespace.Protected(),
-				suspended: suspended,
+			}
+			if stateFile != nil {
+				table[i].suspended = stateFile.SuspendedReplicas()
+				table[i].notes = stateFile.notes()
 			}
 			return nil
 		})
@@ -167,4 +282,10 @@ func (c cliConfig) printStatus(statusTable []status) {
 	}
 	w.Flush()
 	fmt.Fprintf(c.outWriter, "Total suspended pods: %d\n", total)
+
+	for _, row := range statusTable {
+		for _, note := range row.notes {
+			fmt.Fprintf(c.outWriter, "%s: %s\n", row.name, note)
+		}
+	}
 }
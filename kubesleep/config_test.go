@@ -5,11 +5,13 @@ import (
 	"context"
 	"github.com/stretchr/testify/mock"
 	"io"
+	"os"
+	"strings"
 )
 
-var brokenK8SFactory = func() (K8S, error) { return nil, errExpected }
+var brokenK8SFactory = func(K8SOptions) (K8S, error) { return nil, errExpected }
 
-var placeholderK8S = func() (K8S, error) { return nil, nil }
+var placeholderK8S = func(K8SOptions) (K8S, error) { return nil, nil }
 
 func (s *Unittest) TestSuspendBrokenK8SFactory() {
 	err := cliConfig{namespaces: []string{"foo"}, outWriter: io.Discard}.suspend(context.TODO(), brokenK8SFactory)
@@ -191,3 +193,206 @@ func (s *Unittest) TestStatusSingleNamespace_Suspended() {
 	s.Contains(actual, "suspended")
 	s.Contains(actual, "Total suspended pods: 2")
 }
+
+func (s *Unittest) TestSuspendMissingRulesFile() {
+	err := cliConfig{namespaces: []string{"foo"}, rulesFile: "/does/not/exist.yaml", outWriter: io.Discard}.suspend(context.TODO(), placeholderK8S)
+
+	s.Require().ErrorIs(err, os.ErrNotExist)
+}
+
+func (s *Unittest) TestSuspendPassesOptionsToFactory() {
+	rulesFile := s.writeRulesFile("rules:\n  - {apiVersion: kubevirt.io/v1, kind: VirtualMachine, path: .spec.runStrategy, asleep: Halted}\n")
+	var actual K8SOptions
+	factory := func(options K8SOptions) (K8S, error) {
+		actual = options
+		return nil, errExpected
+	}
+
+	err := cliConfig{
+		namespaces: []string{"foo"},
+		scaleDeny:  []string{"CloneSet.apps.kruise.io"},
+		rulesFile:  rulesFile,
+		activeJobs: "suspend",
+		outWriter:  io.Discard,
+	}.suspend(context.TODO(), factory)
+
+	s.Require().Equal(errExpected, err)
+	s.Require().Equal([]string{"CloneSet.apps.kruise.io"}, actual.ScaleDeny)
+	s.Require().Equal(SuspendActiveJobs, actual.ActiveJobs)
+	s.Require().Len(actual.FieldRules, 1)
+	s.Require().Equal("VirtualMachine", actual.FieldRules[0].Kind)
+}
+
+func (s *Unittest) TestWakePassesSkipMissedRunsToFactory() {
+	var actual K8SOptions
+	factory := func(options K8SOptions) (K8S, error) {
+		actual = options
+		return nil, errExpected
+	}
+
+	err := cliConfig{namespaces: []string{"foo"}, skipMissed: true, outWriter: io.Discard}.wake(context.TODO(), factory)
+
+	s.Require().Equal(errExpected, err)
+	s.Require().True(actual.SkipMissedRuns)
+}
+
+func (s *Unittest) TestStatusFlagsAutoscaledWorkloads() {
+	var out bytes.Buffer
+	k8s, factory := NewMockK8S()
+	k8s.On("GetSuspendableNamespace", mock.Anything, "foo").Return(NewSuspendableNamespace("foo", false), nil)
+	sus := NewSuspendable(Deplyoment, "web", int32(7), nil).WithAutoscaler(Autoscaler{Name: "web-hpa", MinReplicas: 2, MaxReplicas: 10})
+	state := NewSuspendState(map[string]Suspendable{sus.Identifier(): sus}, true)
+	k8s.On("GetStateFile", mock.Anything, "foo").Return(&state, (*MockStateFileActions)(nil), nil)
+
+	err := cliConfig{namespaces: []string{"foo"}, outWriter: &out}.status(context.TODO(), factory)
+
+	k8s.AssertExpectations(s.T())
+	s.Require().NoError(err)
+	s.Contains(out.String(), "Total suspended pods: 7\n")
+	s.Contains(out.String(), "foo: Deployment web is managed by HorizontalPodAutoscaler web-hpa (2-10 replicas) and wakes with 2 replicas\n")
+}
+
+func (s *Unittest) TestStatusShowsHibernatedClusters() {
+	var out bytes.Buffer
+	k8s, factory := NewMockK8S()
+	k8s.On("GetSuspendableNamespace", mock.Anything, "foo").Return(NewSuspendableNamespace("foo", false), nil)
+	cluster := NewSuspendable(PostgresCluster, "pg", int32(3), nil)
+	state := NewSuspendState(map[string]Suspendable{cluster.Identifier(): cluster}, true)
+	k8s.On("GetStateFile", mock.Anything, "foo").Return(&state, (*MockStateFileActions)(nil), nil)
+
+	err := cliConfig{namespaces: []string{"foo"}, outWriter: &out}.status(context.TODO(), factory)
+
+	k8s.AssertExpectations(s.T())
+	s.Require().NoError(err)
+	s.Contains(out.String(), "Total suspended pods: 3\n")
+	s.Contains(out.String(), "foo: CloudNativePG Cluster pg is hibernated (3 instances)\n")
+}
+
+func (s *Unittest) TestStatusWarnsAboutOperatorManagedWorkloads() {
+	var out bytes.Buffer
+	k8s, factory := NewMockK8S()
+	k8s.On("GetSuspendableNamespace", mock.Anything, "foo").Return(NewSuspendableNamespace("foo", false), nil)
+	unmanaged := NewSuspendable(OperatorManaged, "web", 0, nil).
+		WithResource("apps/v1", "Deployment").
+		WithOwner(Owner{APIVersion: "example.com/v1", Kind: "App", Name: "web"})
+	managed := NewSuspendable(OperatorManaged, "kafka", 0, nil).
+		WithResource("apps/v1", "StatefulSet").
+		WithOwner(Owner{APIVersion: "kafka.strimzi.io/v1beta2", Kind: "Kafka", Name: "kafka", Suspended: true})
+	state := NewSuspendState(map[string]Suspendable{unmanaged.Identifier(): unmanaged, managed.Identifier(): managed}, true)
+	k8s.On("GetStateFile", mock.Anything, "foo").Return(&state, (*MockStateFileActions)(nil), nil)
+
+	err := cliConfig{namespaces: []string{"foo"}, outWriter: &out}.status(context.TODO(), factory)
+
+	k8s.AssertExpectations(s.T())
+	s.Require().NoError(err)
+	s.Contains(out.String(), "foo: warning: Deployment web is managed by App web and keeps running. Add a field rule for App.example.com to suspend it\n")
+	s.Contains(out.String(), "foo: StatefulSet kafka is managed by Kafka kafka, which is suspended instead\n")
+}
+
+func (s *Unittest) TestStatusCountsReleasedLoadBalancers() {
+	var out bytes.Buffer
+	k8s, factory := NewMockK8S()
+	k8s.On("GetSuspendableNamespace", mock.Anything, "foo").Return(NewSuspendableNamespace("foo", false), nil)
+	web := NewSuspendable(LoadBalancer, "web", 0, nil).WithState([]byte(`{"type":"LoadBalancer"}`))
+	api := NewSuspendable(LoadBalancer, "api", 0, nil).WithState([]byte(`{"type":"LoadBalancer"}`))
+	state := NewSuspendState(map[string]Suspendable{web.Identifier(): web, api.Identifier(): api}, true)
+	k8s.On("GetStateFile", mock.Anything, "foo").Return(&state, (*MockStateFileActions)(nil), nil)
+
+	err := cliConfig{namespaces: []string{"foo"}, outWriter: &out}.status(context.TODO(), factory)
+
+	k8s.AssertExpectations(s.T())
+	s.Require().NoError(err)
+	s.Contains(out.String(), "foo: 2 load balancers released by switching their Services to ClusterIP\n")
+}
+
+func (s *Unittest) mockRecover(recovered []RecoveredSuspendable) (*mockK8S, K8SFactory) {
+	k8s, factory := NewMockK8S()
+	k8s.On("GetSuspendableNamespace", mock.Anything, "foo").Return(NewSuspendableNamespace("foo", false), nil)
+	k8s.On("GetStateFile", mock.Anything, "foo").Return((*SuspendState)(nil), (*MockStateFileActions)(nil), StatefileNotFoundError("not found"))
+	k8s.On("GetSuspendAnnotations", mock.Anything, "foo").Return([]map[string]string(nil), nil)
+	k8s.On("RecoverSuspendables", mock.Anything, "foo").Return(recovered, nil)
+	return k8s, factory
+}
+
+var TEST_RECOVERED = []RecoveredSuspendable{
+	{NewSuspendable(Deplyoment, "web", 3, nil), "Helm release web"},
+	{NewSuspendable(CronJob, "backup", 0, nil).WithSuspended(false), "last-applied-configuration"},
+	{NewSuspendable(StatefulSet, "db", 1, nil), ""},
+}
+
+func (s *Unittest) TestRecoverWritesConfirmedState() {
+	var out bytes.Buffer
+	k8s, factory := s.mockRecover(TEST_RECOVERED)
+	var written map[string]string
+	k8s.On("CreateStateFile", mock.Anything, "foo", mock.Anything).Run(func(args mock.Arguments) {
+		written = args.Get(2).(map[string]string)
+	}).Return(&MockStateFileActions{}, nil)
+
+	err := cliConfig{namespaces: []string{"foo"}, outWriter: &out}.recover(context.TODO(), factory, strings.NewReader("y\n"))
+
+	k8s.AssertExpectations(s.T())
+	s.Require().NoError(err)
+	s.Contains(out.String(), "Deployment   web     3 replicas  Helm release web")
+	s.Contains(out.String(), "CronJob      backup  running     last-applied-configuration")
+	s.Contains(out.String(), "StatefulSet  db      -           not recovered, stays at 0")
+	s.Contains(out.String(), "Recovered namespace foo. Run kubesleep wake -n foo to wake it\n")
+
+	web, backup := TEST_RECOVERED[0].Suspendable, TEST_RECOVERED[1].Suspendable
+	s.Require().Equal(&SuspendState{map[string]Suspendable{web.Identifier(): web, backup.Identifier(): backup}, true}, ReadSuspendState(written))
+}
+
+func (s *Unittest) TestRecoverDeclined() {
+	var out bytes.Buffer
+	k8s, factory := s.mockRecover(TEST_RECOVERED)
+
+	err := cliConfig{namespaces: []string{"foo"}, outWriter: &out}.recover(context.TODO(), factory, strings.NewReader("\n"))
+
+	k8s.AssertExpectations(s.T())
+	s.Require().NoError(err)
+	s.Contains(out.String(), "Skipped namespace foo\n")
+}
+
+func (s *Unittest) TestRecoverWithYes() {
+	k8s, factory := s.mockRecover(TEST_RECOVERED)
+	k8s.On("CreateStateFile", mock.Anything, "foo", mock.Anything).Return(&MockStateFileActions{}, nil)
+
+	err := cliConfig{namespaces: []string{"foo"}, outWriter: io.Discard, yes: true}.recover(context.TODO(), factory, strings.NewReader(""))
+
+	k8s.AssertExpectations(s.T())
+	s.Require().NoError(err)
+}
+
+func (s *Unittest) TestRecoverNothing() {
+	var out bytes.Buffer
+	k8s, factory := s.mockRecover([]RecoveredSuspendable{{NewSuspendable(Deplyoment, "web", 1, nil), ""}})
+
+	err := cliConfig{namespaces: []string{"foo"}, outWriter: &out}.recover(context.TODO(), factory, strings.NewReader("y\n"))
+
+	k8s.AssertExpectations(s.T())
+	s.Require().NoError(err)
+	s.Require().Equal("Nothing to recover in namespace foo\n", out.String())
+}
+
+func (s *Unittest) TestRecoverRefusesExistingState() {
+	k8s, factory := NewMockK8S()
+	state := NewSuspendState(map[string]Suspendable{}, true)
+	k8s.On("GetSuspendableNamespace", mock.Anything, "foo").Return(NewSuspendableNamespace("foo", false), nil)
+	k8s.On("GetStateFile", mock.Anything, "foo").Return(&state, (*MockStateFileActions)(nil), nil)
+
+	err := cliConfig{namespaces: []string{"foo"}, outWriter: io.Discard}.recover(context.TODO(), factory, strings.NewReader("y\n"))
+
+	k8s.AssertExpectations(s.T())
+	s.Require().ErrorContains(err, "namespace foo still has a statefile")
+}
+
+func (s *Unittest) TestRecoverRefusesAnnotatedObjects() {
+	k8s, factory := NewMockK8S()
+	k8s.On("GetSuspendableNamespace", mock.Anything, "foo").Return(NewSuspendableNamespace("foo", false), nil)
+	k8s.On("GetStateFile", mock.Anything, "foo").Return((*SuspendState)(nil), (*MockStateFileActions)(nil), StatefileNotFoundError("not found"))
+	k8s.On("GetSuspendAnnotations", mock.Anything, "foo").Return([]map[string]string{{SUSPENDABLE_ANNOTATION: "{}"}}, nil)
+
+	err := cliConfig{namespaces: []string{"foo"}, outWriter: io.Discard}.recover(context.TODO(), factory, strings.NewReader("y\n"))
+
+	k8s.AssertExpectations(s.T())
+	s.Require().ErrorContains(err, "carry the suspend annotations")
+}
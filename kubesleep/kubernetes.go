@@ -1,20 +1,82 @@
 package kubesleep
 
-import "context"
+import (
+	"context"
+	"fmt"
+)
 
 type K8S interface {
 	GetSuspendableNamespace(ctx context.Context, namespace string) (SuspendableNamespace, error)
 	GetSuspendableNamespaces(ctx context.Context) ([]SuspendableNamespace, error)
 
 	GetSuspendables(ctx context.Context, namespace string) (map[string]Suspendable, error)
-	ScaleSuspendable(ctx context.Context, namespace string, manifestType ManifestType, name string, replicas int32) error
+	ScaleSuspendable(ctx context.Context, namespace string, suspendable Suspendable) error
+
+	// AnnotateSuspendable merge patches the annotations of the object the suspendable refers to. Nil values remove
+	// an annotation. Suspendables without an object of their own in the namespace are skipped.
+	AnnotateSuspendable(ctx context.Context, namespace string, suspendable Suspendable, annotations map[string]*string) error
+	// GetSuspendAnnotations returns the annotations of every object carrying a SUSPENDABLE_ANNOTATION.
+	GetSuspendAnnotations(ctx context.Context, namespace string) ([]map[string]string, error)
+	// RecoverSuspendables reconstructs the original state of suspended workloads from other sources than kubesleep's own.
+	RecoverSuspendables(ctx context.Context, namespace string) ([]RecoveredSuspendable, error)
 
 	GetStateFile(ctx context.Context, namespace string) (*SuspendState, SuspendStateActions, error)
 	CreateStateFile(ctx context.Context, namespace string, data map[string]string) (SuspendStateActions, error)
 	DeleteStateFile(ctx context.Context, namespace string) error
 }
 
-type K8SFactory func() (K8S, error)
+// K8SOptions configures which workloads a K8S implementation discovers.
+type K8SOptions struct {
+	// ScaleAllow restricts the generic /scale subresource support to the listed kinds.
+	// An empty list allows every kind. Entries use the kubectl notation: Kind.version.group or Kind.group.
+	ScaleAllow []string
+	// ScaleDeny excludes the listed kinds from the generic /scale subresource support.
+	ScaleDeny []string
+	// FieldRules suspend custom resources by setting a field instead of scaling them.
+	FieldRules []FieldRule
+	// ActiveJobs decides what happens to running Jobs of a CronJob or Workflows of a CronWorkflow when it is suspended.
+	ActiveJobs ActiveJobPolicy
+	// SkipMissedRuns prevents CronJobs from catching up on schedules missed while they were suspended.
+	SkipMissedRuns bool
+	// BarePods enables suspending Pods without ownerReferences by deleting and later recreating them.
+	BarePods bool
+	// DiscardEmptyDir allows suspending bare Pods with emptyDir volumes, losing their contents.
+	DiscardEmptyDir bool
+	// LoadBalancers enables switching Services of type LoadBalancer to ClusterIP, releasing their load balancers.
+	LoadBalancers bool
+	// DownscalerImport reads the downscaler/original-replicas annotation of Deployments and StatefulSets
+	// scaled to zero by kube-downscaler and skips workloads annotated with downscaler/exclude.
+	DownscalerImport bool
+	// DownscalerAnnotations writes the downscaler/original-replicas annotation on suspend for tools that expect it.
+	DownscalerAnnotations bool
+	// Plugins are executables providing custom suspendable types.
+	Plugins []Plugin
+}
+
+// ActiveJobPolicy decides what happens to Jobs a CronJob started before it was suspended.
+type ActiveJobPolicy string
+
+const (
+	// KeepActiveJobs lets running Jobs finish. This is the default.
+	KeepActiveJobs ActiveJobPolicy = ""
+	// SuspendActiveJobs suspends running Jobs and resumes them on wake.
+	SuspendActiveJobs ActiveJobPolicy = "suspend"
+	// TerminateActiveJobs deletes running Jobs.
+	TerminateActiveJobs ActiveJobPolicy = "terminate"
+)
+
+func ParseActiveJobPolicy(policy string) (ActiveJobPolicy, error) {
+	switch policy {
+	case "", "keep":
+		return KeepActiveJobs, nil
+	case string(SuspendActiveJobs), string(TerminateActiveJobs):
+		return ActiveJobPolicy(policy), nil
+	default:
+		return "", CliArgumentError(fmt.Sprintf("Invalid active jobs policy %q.\nmust be one of keep, suspend or terminate.", policy))
+	}
+}
+
+type K8SFactory func(K8SOptions) (K8S, error)
 
 type StatefileAlreadyExistsError string
 
@@ -27,3 +89,10 @@ func (e StatefileNotFoundError) Error() string { return string(e) }
 type NamespaceTerminatingError string
 
 func (e NamespaceTerminatingError) Error() string { return string(e) }
+
+// RecoveredSuspendable is a suspendable reconstructed without a statefile. Source describes where its original
+// state was found and is empty if it couldn't be recovered.
+type RecoveredSuspendable struct {
+	Suspendable Suspendable
+	Source      string
+}
@@ -61,11 +61,26 @@ func (m *mockK8S) GetSuspendables(ctx context.Context, ns string) (map[string]Su
 	return args.Get(0).(map[string]Suspendable), args.Error(1)
 }
 
-func (m *mockK8S) ScaleSuspendable(ctx context.Context, ns string, manifestType ManifestType, name string, replicas int32) error {
-	args := m.Called(ctx, ns, manifestType, name, replicas)
+func (m *mockK8S) ScaleSuspendable(ctx context.Context, ns string, suspendable Suspendable) error {
+	args := m.Called(ctx, ns, suspendable)
 	return args.Error(0)
 }
 
+func (m *mockK8S) AnnotateSuspendable(ctx context.Context, ns string, suspendable Suspendable, annotations map[string]*string) error {
+	args := m.Called(ctx, ns, suspendable, annotations)
+	return args.Error(0)
+}
+
+func (m *mockK8S) GetSuspendAnnotations(ctx context.Context, ns string) ([]map[string]string, error) {
+	args := m.Called(ctx, ns)
+	return args.Get(0).([]map[string]string), args.Error(1)
+}
+
+func (m *mockK8S) RecoverSuspendables(ctx context.Context, ns string) ([]RecoveredSuspendable, error) {
+	args := m.Called(ctx, ns)
+	return args.Get(0).([]RecoveredSuspendable), args.Error(1)
+}
+
 func (m *mockK8S) GetCronJobs(ns string) (map[string]Suspendable, error) {
 	args := m.Called(ns)
 	return args.Get(0).(map[string]Suspendable), args.Error(1)
@@ -78,5 +93,21 @@ func (m *mockK8S) ScaleCronJob(ns, name string, suspended int32) error {
 
 func NewMockK8S() (*mockK8S, K8SFactory) {
 	k8s := &mockK8S{}
-	return k8s, func() (K8S, error) { return k8s, nil }
+	return k8s, func(K8SOptions) (K8S, error) { return k8s, nil }
+}
+
+func (s *Unittest) TestParseActiveJobPolicy() {
+	for input, expected := range map[string]ActiveJobPolicy{
+		"":          KeepActiveJobs,
+		"keep":      KeepActiveJobs,
+		"suspend":   SuspendActiveJobs,
+		"terminate": TerminateActiveJobs,
+	} {
+		actual, err := ParseActiveJobPolicy(input)
+		s.Require().NoError(err)
+		s.Equal(expected, actual, input)
+	}
+
+	_, err := ParseActiveJobPolicy("pause")
+	s.Require().ErrorAs(err, new(CliArgumentError))
 }
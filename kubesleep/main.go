@@ -11,7 +11,8 @@ import (
 func Main(
 	args []string,
 	initialLogLevel slog.Level,
-	k8sFactory func() (K8S, error),
+	k8sFactory K8SFactory,
+	pluginPath string,
 	versionUpdateCheck func(*http.Client) (string, error),
 	outWriter io.Writer,
 	errWriter io.Writer,
@@ -31,7 +32,7 @@ func Main(
 		}
 	}()
 
-	command, _ := NewParser(args, k8sFactory, SetupLogging)
+	command, _ := NewParser(args, k8sFactory, pluginPath, SetupLogging)
 	command.SetOut(outWriter)
 	command.SetErr(errWriter)
 
@@ -20,6 +20,7 @@ func (s *Unittest) TestMain_Success() {
 		[]string{"kubesleep", "version"},
 		slog.LevelInfo,
 		factory,
+		"",
 		mockVersionUpdateCheck,
 		&outWriter,
 		&errWriter,
@@ -40,6 +41,7 @@ func (s *Unittest) TestMain_UpdateCheck_IgnoreError() {
 		[]string{"kubesleep", "version"},
 		slog.LevelInfo,
 		factory,
+		"",
 		mockVersionUpdateCheck,
 		&outWriter,
 		&errWriter,
@@ -66,6 +68,7 @@ func (s *Unittest) TestMain_UpdateCheck_NonBlocking() {
 		[]string{"kubesleep", "suspend", "-n", "blub"},
 		slog.LevelInfo,
 		factory,
+		"",
 		mockVersionUpdateCheck,
 		&outWriter,
 		&errWriter,
@@ -98,6 +101,7 @@ func (s *Unittest) TestMain_PrintsUpdateWhenReady() {
 		[]string{"kubesleep", "suspend", "-n", "blub"},
 		slog.LevelInfo,
 		factory,
+		"",
 		mockVersionUpdateCheck,
 		&outWriter,
 		&errWriter,
@@ -5,9 +5,9 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"slices"
 
 	"golang.org/x/sync/errgroup"
-	"k8s.io/utils/strings/slices"
 )
 
 var PROTECTED_NAMESPACES = []string{"default", "kube-node-lease", "kube-public", "kube-system", "ingress-nginx", "istio", "local-path"}
@@ -18,7 +18,9 @@ type SuspendableNamespace interface {
 	autoProtected() bool
 	suspend(context.Context, K8S) error
 	wake(context.Context, K8S) error
-	status(context.Context, K8S) (string, int32, error)
+	status(context.Context, K8S) (string, *SuspendState, error)
+	recoverState(context.Context, K8S) ([]RecoveredSuspendable, error)
+	writeRecoveredState(context.Context, K8S, []RecoveredSuspendable) error
 }
 
 type suspendableNamespaceImpl struct {
@@ -44,7 +46,11 @@ func (n *suspendableNamespaceImpl) Name() string {
 }
 
 func (n *suspendableNamespaceImpl) wake(ctx context.Context, k8s K8S) error {
+	var notFound StatefileNotFoundError
 	stateFile, actions, err := k8s.GetStateFile(ctx, n.name)
+	if errors.As(err, &notFound) {
+		stateFile, actions, err = n.stateFromAnnotations(ctx, k8s, err)
+	}
 	if err != nil {
 		return err
 	}
@@ -52,25 +58,60 @@ func (n *suspendableNamespaceImpl) wake(ctx context.Context, k8s K8S) error {
 	if !stateFile.finished {
 		return fmt.Errorf("cannot wake the namespace %s because the namespace is partially suspended. Please first resume / retry the suspend operation", n.name)
 	}
-	g, ctxGroup := errgroup.WithContext(ctx)
-
-	for _, s := range stateFile.suspendables {
-		g.Go(func() error {
-			return repeat(func() error {
-				return s.wake(ctxGroup, n.name, k8s)
+	phases := inPhases(stateFile.suspendables)
+	slices.Reverse(phases)
+	for _, phase := range phases {
+		g, ctxGroup := errgroup.WithContext(ctx)
+		for _, s := range phase {
+			g.Go(func() error {
+				err := repeat(func() error {
+					return s.wake(ctxGroup, n.name, k8s)
+				})
+				if err != nil {
+					return err
+				}
+				return repeat(func() error {
+					return k8s.AnnotateSuspendable(ctxGroup, n.name, s, removeSuspendAnnotations())
+				})
 			})
-		})
+		}
+		if err := g.Wait(); err != nil {
+			return err
+		}
 	}
-	if err := g.Wait(); err != nil {
-		return err
+	if actions == nil {
+		return nil
 	}
 	return actions.Delete(ctx)
 }
 
+// stateFromAnnotations falls back to the suspendables recorded on the suspended objects if the statefile is missing.
+// The notFound error is returned if there are no records either.
+// There is no statefile to delete afterwards, so the returned actions are always nil.
+func (n *suspendableNamespaceImpl) stateFromAnnotations(ctx context.Context, k8s K8S, notFound error) (*SuspendState, SuspendStateActions, error) {
+	annotations, err := k8s.GetSuspendAnnotations(ctx, n.name)
+	if err != nil {
+		return nil, nil, err
+	}
+	stateFile, err := suspendStateFromAnnotations(annotations)
+	if err != nil {
+		return nil, nil, err
+	}
+	if stateFile == nil {
+		return nil, nil, notFound
+	}
+	slog.Warn("Statefile not found; waking the namespace from the annotations of the suspended objects", "namespace", n.name, "suspendables", len(stateFile.suspendables))
+	return stateFile, nil, nil
+}
+
 func (n *suspendableNamespaceImpl) ensureStateFile(ctx context.Context, k8s K8S, stateFile *SuspendState) (*SuspendState, SuspendStateActions, error) {
 	var alreadyExists StatefileAlreadyExistsError
 
-	actions, err := k8s.CreateStateFile(ctx, n.name, stateFile.Write())
+	data := stateFile.Write()
+	if err := checkStateFileSize(data); err != nil {
+		return nil, nil, err
+	}
+	actions, err := k8s.CreateStateFile(ctx, n.name, data)
 	if err == nil {
 		slog.Debug("No existing statefile found. Creating a new one to save the starting conditions.", "namespace", n.name)
 		return stateFile, actions, nil
@@ -86,7 +127,11 @@ func (n *suspendableNamespaceImpl) ensureStateFile(ctx context.Context, k8s K8S,
 	if err != nil {
 		return nil, nil, err
 	}
-	return existingStateFile.merge(stateFile), actions, nil
+	merged := existingStateFile.merge(stateFile)
+	if err := checkStateFileSize(merged.Write()); err != nil {
+		return nil, nil, err
+	}
+	return merged, actions, nil
 }
 
 func (n *suspendableNamespaceImpl) suspend(ctx context.Context, k8s K8S) error {
@@ -105,33 +150,103 @@ func (n *suspendableNamespaceImpl) suspend(ctx context.Context, k8s K8S) error {
 
 	slog.Debug("Suspending workloads", "stateFile", stateFile, "namespace", n.name)
 
-	g, ctxGroup := errgroup.WithContext(ctx)
-	for _, sus := range suspendables {
-		g.Go(func() error {
-			return repeat(func() error {
-				return sus.Suspend(ctxGroup)
-			})
-		})
+	runID := newSuspendRunID()
+	// Suspendables kept from an earlier run, e.g. Services already switched to ClusterIP, are recorded again
+	// so the annotations of the latest run cover the whole namespace.
+	for id, recorded := range stateFile.suspendables {
+		if _, ok := suspendables[id]; !ok {
+			if err := n.annotate(ctx, k8s, recorded, runID); err != nil {
+				return err
+			}
+		}
 	}
-	if err := g.Wait(); err != nil {
-		return err
+
+	for _, phase := range inPhases(suspendables) {
+		g, ctxGroup := errgroup.WithContext(ctx)
+		for _, sus := range phase {
+			g.Go(func() error {
+				// The statefile keeps the values of an earlier, aborted suspend run. The annotations have to match them.
+				if err := n.annotate(ctxGroup, k8s, stateFile.suspendables[sus.Identifier()], runID); err != nil {
+					return err
+				}
+				return repeat(func() error {
+					return sus.Suspend(ctxGroup)
+				})
+			})
+		}
+		if err := g.Wait(); err != nil {
+			return err
+		}
 	}
 
 	stateFile.finished = true
 	return actions.Update(ctx, stateFile.Write())
 }
 
-func (n *suspendableNamespaceImpl) status(ctx context.Context, k8s K8S) (string, int32, error) {
+// annotate records the suspendable on the suspended object as a fallback for a lost statefile.
+func (n *suspendableNamespaceImpl) annotate(ctx context.Context, k8s K8S, recorded Suspendable, runID string) error {
+	annotations, err := recorded.suspendAnnotations(runID)
+	if err != nil {
+		return err
+	}
+	return repeat(func() error {
+		return k8s.AnnotateSuspendable(ctx, n.name, recorded, annotations)
+	})
+}
+
+// recoverState reconstructs the suspendables of a namespace whose statefile and annotations are lost.
+func (n *suspendableNamespaceImpl) recoverState(ctx context.Context, k8s K8S) ([]RecoveredSuspendable, error) {
+	var notFound StatefileNotFoundError
+	_, _, err := k8s.GetStateFile(ctx, n.name)
+	if err == nil {
+		return nil, fmt.Errorf("namespace %s still has a statefile; run kubesleep wake instead", n.name)
+	}
+	if !errors.As(err, &notFound) {
+		return nil, err
+	}
+
+	annotations, err := k8s.GetSuspendAnnotations(ctx, n.name)
+	if err != nil {
+		return nil, err
+	}
+	if len(annotations) > 0 {
+		return nil, fmt.Errorf("objects in namespace %s carry the suspend annotations; run kubesleep wake instead", n.name)
+	}
+
+	return k8s.RecoverSuspendables(ctx, n.name)
+}
+
+// writeRecoveredState writes the recovered suspendables as the statefile of a suspended namespace.
+// Suspendables without a source are left out.
+func (n *suspendableNamespaceImpl) writeRecoveredState(ctx context.Context, k8s K8S, recovered []RecoveredSuspendable) error {
+	suspendables := map[string]Suspendable{}
+	for _, r := range recovered {
+		if r.Source != "" {
+			suspendables[r.Suspendable.Identifier()] = r.Suspendable
+		}
+	}
+	stateFile := NewSuspendState(suspendables, true)
+
+	data := stateFile.Write()
+	if err := checkStateFileSize(data); err != nil {
+		return err
+	}
+	_, err := k8s.CreateStateFile(ctx, n.name, data)
+	return err
+}
+
+// status returns a short description of the namespace and its statefile, or nil if it's running.
+func (n *suspendableNamespaceImpl) status(ctx context.Context, k8s K8S) (string, *SuspendState, error) {
 	var notFound StatefileNotFoundError
 	stateFile, _, err := k8s.GetStateFile(ctx, n.name)
 	if errors.As(err, &notFound) {
-		return "running", 0, nil
+		return "running", nil, nil
 	}
 	if err != nil {
-		return "", 0, err
+		return "", nil, err
 	}
 	if stateFile.finished {
-		return "suspended", stateFile.SuspendedReplicas(), nil
+		return "suspended", stateFile, nil
 	}
-	return "suspending or suspension aborted", stateFile.SuspendedReplicas(), nil
+	return "suspending or suspension aborted", stateFile, nil
 }
@@ -3,6 +3,7 @@ package kubesleep
 import (
 	"context"
 	"log/slog"
+	"strings"
 
 	"github.com/stretchr/testify/mock"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -16,7 +17,7 @@ func (s *Unittest) TestNamespaceWake() {
 	stateFile := TEST_SUSPEND_STATE_FILE
 	stateFile.finished = true
 	k8s.On("GetStateFile", mock.Anything, "foo").Return(&stateFile, (*MockStateFileActions)(nil), nil)
-	k8s.On("ScaleSuspendable", mock.Anything, "foo", mock.Anything, mock.Anything, mock.Anything).Return(errExpected)
+	k8s.On("ScaleSuspendable", mock.Anything, "foo", mock.Anything).Return(errExpected)
 
 	err := NewSuspendableNamespace("foo", true).wake(context.TODO(), k8s)
 
@@ -70,6 +71,7 @@ func (s *Unittest) TestSuspendConflict() {
 	}
 	k8s.On("GetSuspendables", mock.Anything, "foo").Return(map[string]Suspendable{sus.Identifier(): sus}, nil)
 	k8s.On("CreateStateFile", mock.Anything, "foo", mock.Anything).Return(&actions, nil)
+	k8s.On("AnnotateSuspendable", mock.Anything, "foo", mock.Anything, mock.Anything).Return(nil)
 
 	err := NewSuspendableNamespace("foo", true).suspend(context.TODO(), k8s)
 
@@ -85,6 +87,7 @@ func (s *Unittest) TestNamespaceSuspend() {
 	sus.Suspend = func(context.Context) error { return nil }
 	k8s.On("GetSuspendables", mock.Anything, "foo").Return(map[string]Suspendable{sus.Identifier(): sus}, nil)
 	k8s.On("CreateStateFile", mock.Anything, "foo", mock.Anything).Return(&actions, nil)
+	k8s.On("AnnotateSuspendable", mock.Anything, "foo", mock.Anything, mock.Anything).Return(nil)
 	actions.On("Update", mock.Anything, mock.Anything).Return(nil)
 
 	err := NewSuspendableNamespace("foo", true).suspend(context.TODO(), k8s)
@@ -119,3 +122,163 @@ func (s *Unittest) TestNamespaceEnsureStateFile() {
 	k8s.AssertExpectations(s.T())
 	s.Require().NoError(err)
 }
+
+func (s *Unittest) TestNamespaceSuspendStatefileTooLarge() {
+	k8s, _ := NewMockK8S()
+	suspended := false
+	pod := NewSuspendable(BarePod, "huge", 1, func(context.Context) error {
+		suspended = true
+		return nil
+	}).WithState([]byte(`"` + strings.Repeat("x", STATE_FILE_SIZE_LIMIT) + `"`))
+	k8s.On("GetSuspendables", mock.Anything, "foo").Return(map[string]Suspendable{pod.Identifier(): pod}, nil)
+
+	err := NewSuspendableNamespace("foo", true).suspend(context.TODO(), k8s)
+
+	k8s.AssertExpectations(s.T())
+	s.Require().ErrorContains(err, "exceeds the ConfigMap size limit")
+	s.Require().False(suspended)
+}
+
+func (s *Unittest) TestNamespaceSuspendStopsGitOpsFirst() {
+	k8s, _ := NewMockK8S()
+	actions := MockStateFileActions{}
+	var order []string
+	record := func(name string) func(context.Context) error {
+		return func(context.Context) error {
+			order = append(order, name)
+			return nil
+		}
+	}
+	app := NewSuspendable(ArgoApplication, "argocd/app", 0, record("app"))
+	deployment := NewSuspendable(Deplyoment, "web", 2, record("web"))
+	k8s.On("GetSuspendables", mock.Anything, "foo").Return(map[string]Suspendable{app.Identifier(): app, deployment.Identifier(): deployment}, nil)
+	k8s.On("CreateStateFile", mock.Anything, "foo", mock.Anything).Return(&actions, nil)
+	k8s.On("AnnotateSuspendable", mock.Anything, "foo", mock.Anything, mock.Anything).Return(nil)
+	actions.On("Update", mock.Anything, mock.Anything).Return(nil)
+
+	err := NewSuspendableNamespace("foo", true).suspend(context.TODO(), k8s)
+
+	k8s.AssertExpectations(s.T())
+	s.Require().NoError(err)
+	s.Require().Equal([]string{"app", "web"}, order)
+}
+
+func (s *Unittest) TestNamespaceWakeRestoresGitOpsLast() {
+	k8s, _ := NewMockK8S()
+	actions := MockStateFileActions{}
+	app := NewSuspendable(ArgoApplication, "argocd/app", 0, nil)
+	deployment := NewSuspendable(Deplyoment, "web", 2, nil)
+	stateFile := NewSuspendState(map[string]Suspendable{app.Identifier(): app, deployment.Identifier(): deployment}, true)
+	var order []string
+	k8s.On("GetStateFile", mock.Anything, "foo").Return(&stateFile, &actions, nil)
+	k8s.On("ScaleSuspendable", mock.Anything, "foo", mock.Anything).Run(func(args mock.Arguments) {
+		order = append(order, args.Get(2).(Suspendable).Name())
+	}).Return(nil)
+	k8s.On("AnnotateSuspendable", mock.Anything, "foo", mock.Anything, mock.Anything).Return(nil)
+	actions.On("Delete", mock.Anything).Return(nil)
+
+	err := NewSuspendableNamespace("foo", true).wake(context.TODO(), k8s)
+
+	k8s.AssertExpectations(s.T())
+	s.Require().NoError(err)
+	s.Require().Equal([]string{"web", "argocd/app"}, order)
+}
+
+func (s *Unittest) TestNamespaceWakesDatabasesFirst() {
+	k8s, _ := NewMockK8S()
+	actions := MockStateFileActions{}
+	cluster := NewSuspendable(PostgresCluster, "pg", 3, nil)
+	deployment := NewSuspendable(Deplyoment, "web", 2, nil)
+	stateFile := NewSuspendState(map[string]Suspendable{cluster.Identifier(): cluster, deployment.Identifier(): deployment}, true)
+	var order []string
+	k8s.On("GetStateFile", mock.Anything, "foo").Return(&stateFile, &actions, nil)
+	k8s.On("ScaleSuspendable", mock.Anything, "foo", mock.Anything).Run(func(args mock.Arguments) {
+		order = append(order, args.Get(2).(Suspendable).Name())
+	}).Return(nil)
+	k8s.On("AnnotateSuspendable", mock.Anything, "foo", mock.Anything, mock.Anything).Return(nil)
+	actions.On("Delete", mock.Anything).Return(nil)
+
+	err := NewSuspendableNamespace("foo", true).wake(context.TODO(), k8s)
+
+	k8s.AssertExpectations(s.T())
+	s.Require().NoError(err)
+	s.Require().Equal([]string{"pg", "web"}, order)
+}
+
+func (s *Unittest) TestNamespaceSuspendPausesKafkaAfterNodePools() {
+	k8s, _ := NewMockK8S()
+	actions := MockStateFileActions{}
+	var order []string
+	record := func(name string) func(context.Context) error {
+		return func(context.Context) error {
+			order = append(order, name)
+			return nil
+		}
+	}
+	kafka := NewSuspendable(Kafka, "kafka", 0, record("kafka"))
+	nodePool := NewSuspendable(KafkaNodePool, "brokers", 3, record("brokers"))
+	k8s.On("GetSuspendables", mock.Anything, "foo").Return(map[string]Suspendable{kafka.Identifier(): kafka, nodePool.Identifier(): nodePool}, nil)
+	k8s.On("CreateStateFile", mock.Anything, "foo", mock.Anything).Return(&actions, nil)
+	k8s.On("AnnotateSuspendable", mock.Anything, "foo", mock.Anything, mock.Anything).Return(nil)
+	actions.On("Update", mock.Anything, mock.Anything).Return(nil)
+
+	err := NewSuspendableNamespace("foo", true).suspend(context.TODO(), k8s)
+
+	k8s.AssertExpectations(s.T())
+	s.Require().NoError(err)
+	s.Require().Equal([]string{"brokers", "kafka"}, order)
+}
+
+func (s *Unittest) TestNamespaceSuspendAnnotatesRecordedValues() {
+	k8s, _ := NewMockK8S()
+	actions := MockStateFileActions{}
+	recorded := NewSuspendable(Deplyoment, "web", 3, nil)
+	service := NewSuspendable(LoadBalancer, "public", 0, nil).WithState([]byte(`{"type":"LoadBalancer"}`))
+	existing := NewSuspendState(map[string]Suspendable{recorded.Identifier(): recorded, service.Identifier(): service}, false)
+	current := NewSuspendable(Deplyoment, "web", 0, func(context.Context) error { return nil })
+	k8s.On("GetSuspendables", mock.Anything, "foo").Return(map[string]Suspendable{current.Identifier(): current}, nil)
+	k8s.On("CreateStateFile", mock.Anything, "foo", mock.Anything).Return((*MockStateFileActions)(nil), StatefileAlreadyExistsError("foobar"))
+	k8s.On("GetStateFile", mock.Anything, "foo").Return(&existing, &actions, nil)
+	annotated := map[string]map[string]*string{}
+	k8s.On("AnnotateSuspendable", mock.Anything, "foo", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		annotated[args.Get(2).(Suspendable).Name()] = args.Get(3).(map[string]*string)
+	}).Return(nil)
+	actions.On("Update", mock.Anything, mock.Anything).Return(nil)
+
+	err := NewSuspendableNamespace("foo", true).suspend(context.TODO(), k8s)
+
+	k8s.AssertExpectations(s.T())
+	s.Require().NoError(err)
+	s.Require().Len(annotated, 2)
+	s.Require().JSONEq(`{"ManifestType":0,"Name":"web","Replicas":3}`, *annotated["web"][SUSPENDABLE_ANNOTATION])
+	s.Require().Equal(*annotated["web"][SUSPEND_RUN_ANNOTATION], *annotated["public"][SUSPEND_RUN_ANNOTATION])
+}
+
+func (s *Unittest) TestNamespaceWakeFallsBackToAnnotations() {
+	k8s, _ := NewMockK8S()
+	web := NewSuspendable(Deplyoment, "web", 3, nil)
+	stale := NewSuspendable(Deplyoment, "stale", 2, nil)
+	k8s.On("GetStateFile", mock.Anything, "foo").Return((*SuspendState)(nil), (*MockStateFileActions)(nil), StatefileNotFoundError("not found"))
+	k8s.On("GetSuspendAnnotations", mock.Anything, "foo").Return([]map[string]string{
+		s.annotationsOf(web, "20260102T000000.000000000Z"),
+		s.annotationsOf(stale, "20260101T000000.000000000Z"),
+	}, nil)
+	k8s.On("ScaleSuspendable", mock.Anything, "foo", web).Return(nil)
+	k8s.On("AnnotateSuspendable", mock.Anything, "foo", web, removeSuspendAnnotations()).Return(nil)
+
+	err := NewSuspendableNamespace("foo", true).wake(context.TODO(), k8s)
+
+	k8s.AssertExpectations(s.T())
+	s.Require().NoError(err)
+}
+
+func (s *Unittest) TestNamespaceWakeWithoutStatefileOrAnnotations() {
+	k8s, _ := NewMockK8S()
+	k8s.On("GetStateFile", mock.Anything, "foo").Return((*SuspendState)(nil), (*MockStateFileActions)(nil), StatefileNotFoundError("not found"))
+	k8s.On("GetSuspendAnnotations", mock.Anything, "foo").Return([]map[string]string(nil), nil)
+
+	err := NewSuspendableNamespace("foo", true).wake(context.TODO(), k8s)
+
+	k8s.AssertExpectations(s.T())
+	s.Require().ErrorAs(err, new(StatefileNotFoundError))
+}
@@ -0,0 +1,94 @@
+package kubesleep
+
+import (
+	"encoding/json"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// pluginPrefix is the file name prefix of kubesleep plugin executables.
+const pluginPrefix = "kubesleep-plugin-"
+
+// Plugin is an executable implementing custom suspendable types. kubesleep writes a PluginRequest as JSON
+// to its stdin and reads a PluginResponse from its stdout. A non-zero exit code fails the operation,
+// stderr is used as the error message.
+type Plugin struct {
+	// Name is the file name without the kubesleep-plugin- prefix.
+	Name string
+	Path string
+}
+
+// PluginOperation is the operation a plugin is asked to perform.
+type PluginOperation string
+
+const (
+	// PluginList asks the plugin for the resources it suspends in the namespace.
+	PluginList PluginOperation = "list"
+	// PluginSuspend asks the plugin to suspend a single resource returned by list.
+	PluginSuspend PluginOperation = "suspend"
+	// PluginWake asks the plugin to wake a resource. The request carries the state recorded on suspend.
+	PluginWake PluginOperation = "wake"
+)
+
+// PluginRequest is written to the stdin of a plugin.
+type PluginRequest struct {
+	Operation PluginOperation `json:"operation"`
+	Namespace string          `json:"namespace"`
+	// Resource is set for suspend and wake.
+	Resource *PluginResource `json:"resource,omitempty"`
+}
+
+// PluginResource is a resource managed by a plugin. Kind and name identify it within the namespace,
+// State is an opaque JSON document stored in the statefile and passed back unchanged on wake.
+type PluginResource struct {
+	APIVersion string          `json:"apiVersion,omitempty"`
+	Kind       string          `json:"kind"`
+	Name       string          `json:"name"`
+	Replicas   int32           `json:"replicas,omitempty"`
+	State      json.RawMessage `json:"state,omitempty"`
+}
+
+// PluginResponse is read from the stdout of a plugin. Only list returns resources,
+// suspend and wake may write an empty response.
+type PluginResponse struct {
+	Resources []PluginResource `json:"resources,omitempty"`
+}
+
+// DiscoverPlugins searches the directories of a PATH style list for executables named kubesleep-plugin-<name>.
+// Like kubectl plugins, the first plugin found for a name wins and later ones are ignored with a warning.
+// Empty and relative directories are skipped, like exec.LookPath does, so a plugin in the working directory never runs.
+func DiscoverPlugins(path string) []Plugin {
+	var plugins []Plugin
+	seen := map[string]string{}
+	for _, dir := range filepath.SplitList(path) {
+		if !filepath.IsAbs(dir) {
+			slog.Debug("Skipping relative PATH entry while searching for plugins", "dir", dir)
+			continue
+		}
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+
+		for _, entry := range entries {
+			name, found := strings.CutPrefix(entry.Name(), pluginPrefix)
+			if !found || name == "" || entry.IsDir() {
+				continue
+			}
+			file := filepath.Join(dir, entry.Name())
+			info, err := os.Stat(file)
+			if err != nil || info.Mode().Perm()&0o111 == 0 {
+				continue
+			}
+			if first, ok := seen[name]; ok {
+				slog.Warn("Plugin is shadowed by another plugin of the same name; ignoring it", "plugin", file, "shadowedBy", first)
+				continue
+			}
+			seen[name] = file
+			plugins = append(plugins, Plugin{Name: name, Path: file})
+		}
+	}
+	return plugins
+}
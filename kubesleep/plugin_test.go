@@ -0,0 +1,68 @@
+package kubesleep
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func (s *Unittest) TestDiscoverPlugins() {
+	first, second := s.T().TempDir(), s.T().TempDir()
+	executable := func(dir, name string) string {
+		path := filepath.Join(dir, name)
+		s.Require().NoError(os.WriteFile(path, []byte("#!/bin/sh\n"), 0o700))
+		return path
+	}
+	queues := executable(first, "kubesleep-plugin-queues")
+	executable(second, "kubesleep-plugin-queues")
+	caches := executable(second, "kubesleep-plugin-caches")
+	executable(first, "kubectl-plugin")
+	s.Require().NoError(os.WriteFile(filepath.Join(first, "kubesleep-plugin-readme"), nil, 0o600))
+	s.Require().NoError(os.Mkdir(filepath.Join(first, "kubesleep-plugin-dir"), 0o700))
+
+	plugins := DiscoverPlugins(strings.Join([]string{first, filepath.Join(first, "missing"), second}, string(os.PathListSeparator)))
+
+	s.Require().Equal([]Plugin{
+		{Name: "queues", Path: queues},
+		{Name: "caches", Path: caches},
+	}, plugins)
+}
+
+func (s *Unittest) TestDiscoverPlugins_SkipsRelativeDirectories() {
+	dir := s.T().TempDir()
+	s.Require().NoError(os.Mkdir(filepath.Join(dir, "bin"), 0o700))
+	for _, path := range []string{filepath.Join(dir, "kubesleep-plugin-queues"), filepath.Join(dir, "bin", "kubesleep-plugin-caches")} {
+		s.Require().NoError(os.WriteFile(path, []byte("#!/bin/sh\n"), 0o700))
+	}
+	s.T().Chdir(dir)
+
+	plugins := DiscoverPlugins(strings.Join([]string{"", ".", "bin"}, string(os.PathListSeparator)))
+
+	s.Require().Empty(plugins)
+}
+
+func (s *Unittest) TestParserDiscoversPluginsOnlyForSuspendAndWake() {
+	dir := s.T().TempDir()
+	plugin := filepath.Join(dir, "kubesleep-plugin-queues")
+	s.Require().NoError(os.WriteFile(plugin, []byte("#!/bin/sh\n"), 0o700))
+
+	for command, expected := range map[string][]Plugin{
+		"suspend": {{Name: "queues", Path: plugin}},
+		"import":  {{Name: "queues", Path: plugin}},
+		"wake":    {{Name: "queues", Path: plugin}},
+		"status":  nil,
+		"recover": nil,
+	} {
+		s.Run(command, func() {
+			var actual K8SOptions
+			factory := func(options K8SOptions) (K8S, error) {
+				actual = options
+				return nil, errExpected
+			}
+
+			parser, _ := NewParser([]string{"kubesleep", command, "-n", "foo"}, factory, dir, SetupLogging)
+			s.Require().Equal(errExpected, parser.Execute())
+			s.Require().Equal(expected, actual.Plugins)
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package kubesleep
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/yaml"
+)
+
+// FieldRule suspends every resource of a kind by setting the field at Path to the Asleep value.
+// Path is a JSONPath subset: dot separated field names with optional [index] or [*] array selectors,
+// e.g. .spec.suspend or .spec.nodePools[*].replicas.
+type FieldRule struct {
+	APIVersion string          `json:"apiVersion"`
+	Kind       string          `json:"kind"`
+	Path       string          `json:"path"`
+	Asleep     json.RawMessage `json:"asleep"`
+}
+
+func (r FieldRule) GroupVersionKind() schema.GroupVersionKind {
+	return schema.FromAPIVersionAndKind(r.APIVersion, r.Kind)
+}
+
+type fieldRulesFile struct {
+	Rules []FieldRule `json:"rules"`
+}
+
+// LoadFieldRules reads a YAML or JSON rules file of the form:
+//
+//	rules:
+//	  - apiVersion: kubevirt.io/v1
+//	    kind: VirtualMachine
+//	    path: .spec.runStrategy
+//	    asleep: Halted
+func LoadFieldRules(path string) ([]FieldRule, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rules file %s: %w", path, err)
+	}
+
+	var rulesFile fieldRulesFile
+	if err := yaml.UnmarshalStrict(data, &rulesFile); err != nil {
+		return nil, fmt.Errorf("failed to parse rules file %s: %w", path, err)
+	}
+
+	seen := map[schema.GroupKind]bool{}
+	for i, rule := range rulesFile.Rules {
+		if rule.APIVersion == "" || rule.Kind == "" || rule.Path == "" || len(rule.Asleep) == 0 {
+			return nil, fmt.Errorf("rule %d in %s: apiVersion, kind, path and asleep are required", i, path)
+		}
+		groupKind := rule.GroupVersionKind().GroupKind()
+		if seen[groupKind] {
+			return nil, fmt.Errorf("rule %d in %s: duplicate rule for kind %s", i, path, groupKind)
+		}
+		seen[groupKind] = true
+	}
+	return rulesFile.Rules, nil
+}
@@ -0,0 +1,63 @@
+package kubesleep
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+func (s *Unittest) writeRulesFile(content string) string {
+	path := filepath.Join(s.T().TempDir(), "rules.yaml")
+	s.Require().NoError(os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func (s *Unittest) TestLoadFieldRules() {
+	path := s.writeRulesFile(`
+rules:
+  - apiVersion: kubevirt.io/v1
+    kind: VirtualMachine
+    path: .spec.runStrategy
+    asleep: Halted
+  - apiVersion: example.com/v1
+    kind: Cluster
+    path: .spec.nodePools[*].replicas
+    asleep: 0
+`)
+
+	rules, err := LoadFieldRules(path)
+
+	s.Require().NoError(err)
+	s.Require().Equal(
+		[]FieldRule{
+			{APIVersion: "kubevirt.io/v1", Kind: "VirtualMachine", Path: ".spec.runStrategy", Asleep: json.RawMessage(`"Halted"`)},
+			{APIVersion: "example.com/v1", Kind: "Cluster", Path: ".spec.nodePools[*].replicas", Asleep: json.RawMessage(`0`)},
+		},
+		rules,
+	)
+}
+
+func (s *Unittest) TestLoadFieldRulesInvalid() {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"not yaml", "rules: ["},
+		{"unknown field", "rules:\n  - apiVersion: v1\n    kind: Pod\n    path: .spec\n    asleep: 0\n    unknown: 1\n"},
+		{"missing path", "rules:\n  - apiVersion: v1\n    kind: Pod\n    asleep: 0\n"},
+		{"missing asleep", "rules:\n  - apiVersion: v1\n    kind: Pod\n    path: .spec\n"},
+		{"duplicate kind", "rules:\n  - {apiVersion: a.io/v1, kind: Foo, path: .spec.a, asleep: 0}\n  - {apiVersion: a.io/v2, kind: Foo, path: .spec.b, asleep: 0}\n"},
+	}
+
+	for _, testCase := range tests {
+		s.Run(testCase.name, func() {
+			_, err := LoadFieldRules(s.writeRulesFile(testCase.content))
+			s.Require().Error(err)
+		})
+	}
+}
+
+func (s *Unittest) TestLoadFieldRulesMissingFile() {
+	_, err := LoadFieldRules(filepath.Join(s.T().TempDir(), "missing.yaml"))
+	s.Require().ErrorIs(err, os.ErrNotExist)
+}
@@ -0,0 +1,77 @@
+package kubesleep
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"time"
+)
+
+// Every suspended object carries a copy of its suspendable in annotations. They restore the namespace
+// if the statefile is lost, e.g. because a GitOps tool pruned it.
+const (
+	// SUSPENDABLE_ANNOTATION holds the suspendable as it is recorded in the statefile.
+	SUSPENDABLE_ANNOTATION = "kubesleep.xyz/suspendable"
+	// SUSPEND_RUN_ANNOTATION identifies the suspend run that wrote the SUSPENDABLE_ANNOTATION.
+	SUSPEND_RUN_ANNOTATION = "kubesleep.xyz/suspend-run"
+	// SUSPEND_RECORD_LABEL marks the objects carrying a SUSPENDABLE_ANNOTATION, so they can be found
+	// without knowing which kinds kubesleep suspended.
+	SUSPEND_RECORD_LABEL = "kubesleep.xyz/suspend-record"
+)
+
+// suspendRunLayout is a fixed width UTC timestamp, so later suspend runs sort after earlier ones.
+const suspendRunLayout = "20060102T150405.000000000Z"
+
+func newSuspendRunID() string {
+	return time.Now().UTC().Format(suspendRunLayout)
+}
+
+// suspendAnnotations returns the annotations recording the suspendable for the given suspend run.
+func (s Suspendable) suspendAnnotations(runID string) (map[string]*string, error) {
+	data, err := json.Marshal(s.toDto())
+	if err != nil {
+		return nil, err
+	}
+	value := string(data)
+	return map[string]*string{SUSPENDABLE_ANNOTATION: &value, SUSPEND_RUN_ANNOTATION: &runID}, nil
+}
+
+// removeSuspendAnnotations returns the annotation patch removing the suspendable record.
+func removeSuspendAnnotations() map[string]*string {
+	return map[string]*string{SUSPENDABLE_ANNOTATION: nil, SUSPEND_RUN_ANNOTATION: nil}
+}
+
+// suspendStateFromAnnotations rebuilds a finished SuspendState from the annotations of the latest suspend run.
+// Records of earlier runs are leftovers of an incomplete wake and are ignored.
+// It returns nil if no object carries a record.
+func suspendStateFromAnnotations(annotations []map[string]string) (*SuspendState, error) {
+	runs := map[string][]Suspendable{}
+	for _, annotation := range annotations {
+		var dto suspendableDto
+		if err := json.Unmarshal([]byte(annotation[SUSPENDABLE_ANNOTATION]), &dto); err != nil {
+			return nil, fmt.Errorf("invalid %s annotation: %w", SUSPENDABLE_ANNOTATION, err)
+		}
+		runID := annotation[SUSPEND_RUN_ANNOTATION]
+		runs[runID] = append(runs[runID], dto.fromDto())
+	}
+	if len(runs) == 0 {
+		return nil, nil
+	}
+
+	runIDs := slices.Sorted(maps.Keys(runs))
+	latest := runIDs[len(runIDs)-1]
+	for _, runID := range runIDs[:len(runIDs)-1] {
+		for _, sus := range runs[runID] {
+			slog.Warn("Ignoring the record of an earlier suspend run", "suspendable", sus.Identifier(), "suspendRun", runID, "latestSuspendRun", latest)
+		}
+	}
+
+	suspendables := map[string]Suspendable{}
+	for _, sus := range runs[latest] {
+		suspendables[sus.Identifier()] = sus
+	}
+	state := NewSuspendState(suspendables, true)
+	return &state, nil
+}
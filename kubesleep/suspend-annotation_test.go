@@ -0,0 +1,59 @@
+package kubesleep
+
+func (s *Unittest) annotationsOf(sus Suspendable, runID string) map[string]string {
+	annotations, err := sus.suspendAnnotations(runID)
+	s.Require().NoError(err)
+	result := map[string]string{"unrelated": "value"}
+	for key, value := range annotations {
+		result[key] = *value
+	}
+	return result
+}
+
+func (s *Unittest) TestSuspendAnnotationsRoundTrip() {
+	cronJob := NewSuspendable(CronJob, "backup", 0, nil).WithSuspended(true)
+	daemonSet := NewSuspendable(DaemonSet, "agent", 0, nil).WithState([]byte(`{"nodeSelector":{"disk":"ssd"}}`))
+	scalable := NewSuspendable(Scalable, "clones", 3, nil).WithResource("apps.kruise.io/v1alpha1", "CloneSet")
+
+	actual, err := suspendStateFromAnnotations([]map[string]string{
+		s.annotationsOf(cronJob, "20260101T000000.000000000Z"),
+		s.annotationsOf(daemonSet, "20260101T000000.000000000Z"),
+		s.annotationsOf(scalable, "20260101T000000.000000000Z"),
+	})
+
+	s.Require().NoError(err)
+	s.Require().Equal(&SuspendState{map[string]Suspendable{
+		cronJob.Identifier():   cronJob,
+		daemonSet.Identifier(): daemonSet,
+		scalable.Identifier():  scalable,
+	}, true}, actual)
+}
+
+func (s *Unittest) TestSuspendAnnotationsUseLatestRun() {
+	stale := NewSuspendable(Deplyoment, "stale", 2, nil)
+	web := NewSuspendable(Deplyoment, "web", 3, nil)
+
+	actual, err := suspendStateFromAnnotations([]map[string]string{
+		s.annotationsOf(stale, "20260101T000000.000000000Z"),
+		s.annotationsOf(web, "20260102T000000.000000000Z"),
+	})
+
+	s.Require().NoError(err)
+	s.Require().Equal(&SuspendState{map[string]Suspendable{web.Identifier(): web}, true}, actual)
+}
+
+func (s *Unittest) TestSuspendAnnotationsEmptyOrInvalid() {
+	actual, err := suspendStateFromAnnotations(nil)
+	s.Require().NoError(err)
+	s.Require().Nil(actual)
+
+	_, err = suspendStateFromAnnotations([]map[string]string{{SUSPENDABLE_ANNOTATION: "{", SUSPEND_RUN_ANNOTATION: "x"}})
+	s.Require().ErrorContains(err, "invalid kubesleep.xyz/suspendable annotation")
+}
+
+func (s *Unittest) TestSuspendRunIDsSortChronologically() {
+	earlier := newSuspendRunID()
+	later := newSuspendRunID()
+	s.Require().Len(later, len(suspendRunLayout))
+	s.Require().LessOrEqual(earlier, later)
+}
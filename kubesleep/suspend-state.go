@@ -5,14 +5,24 @@ import (
 	"encoding/json"
 	"fmt"
 	"log/slog"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 // Versioned statefile keys stored in the ConfigMap's data
 const (
 	STATE_FILE_KEY_V1 = "kubesleep.json"
 	STATE_FILE_KEY_V2 = "kubesleep.v2.json"
+	// STATE_FILE_KEY_V3 stores the suspend flag of CronJobs and Jobs explicitly instead of encoding it in the replicas.
+	STATE_FILE_KEY_V3 = "kubesleep.v3.json"
 )
 
+// STATE_FILE_SIZE_LIMIT keeps the statefile below the 1 MiB size limit of ConfigMaps, leaving room for the metadata.
+const STATE_FILE_SIZE_LIMIT = 1000 * 1024
+
+const STATE_FILE_UPGRADE_MESSAGE = `{"message":"please upgrade kubesleep to the latest version to read this statefile"}`
+
 type SuspendStateActions interface {
 	Update(context.Context, map[string]string) error
 	Delete(context.Context) error
@@ -47,14 +57,24 @@ func (s *SuspendState) merge(other *SuspendState) *SuspendState {
 		}
 		result.suspendables[k] = v
 	}
+	// Bare Pods are deleted and LoadBalancer Services switched to ClusterIP on suspend, so a repeated suspend doesn't find them anymore.
+	for k, v := range s.suspendables {
+		if _, ok := result.suspendables[k]; !ok && v.manifestType.vanishesOnSuspend() {
+			result.suspendables[k] = v
+		}
+	}
 	slog.Debug("Merged two statefiles together", "mergedStateFile", result)
 	return &result
 }
 
 func (s *SuspendState) toJson() string {
+	return s.toJsonWith(Suspendable.toDto)
+}
+
+func (s *SuspendState) toJsonWith(toDto func(Suspendable) suspendableDto) string {
 	suspendables := []suspendableDto{}
 	for _, s := range s.suspendables {
-		suspendables = append(suspendables, s.toDto())
+		suspendables = append(suspendables, toDto(s))
 	}
 	stateFileDto := suspendStateDto{
 		suspendables,
@@ -68,42 +88,60 @@ func (s *SuspendState) toJson() string {
 	return string(jsonData)
 }
 
-// hasCronJobs returns true if any suspendable is of type CronJob
-func (s *SuspendState) hasCronJobs() bool {
+// hasIncompatibleTypes returns true if any suspendable has a manifest type not contained in compatible
+func (s *SuspendState) hasIncompatibleTypes(compatible ...ManifestType) bool {
 	for _, sus := range s.suspendables {
-		if sus.manifestType == CronJob {
+		if !slices.Contains(compatible, sus.manifestType) {
 			return true
 		}
 	}
 	return false
 }
 
+// Write serializes the state into all statefile versions.
+// Older versions get the legacy format as long as they understand every manifest type, and an upgrade message otherwise.
 func (s *SuspendState) Write() map[string]string {
-	json := s.toJson()
+	data := map[string]string{
+		STATE_FILE_KEY_V3: s.toJson(),
+		STATE_FILE_KEY_V2: STATE_FILE_UPGRADE_MESSAGE,
+		STATE_FILE_KEY_V1: STATE_FILE_UPGRADE_MESSAGE,
+	}
 
-	if s.hasCronJobs() {
-		return map[string]string{
-			STATE_FILE_KEY_V2: json,
-			STATE_FILE_KEY_V1: `{"message":"please upgrade kubesleep to v0.4.0 or higher to gain CronJob support"}`,
-		}
+	if !s.hasIncompatibleTypes(Deplyoment, StatefulSet, CronJob) {
+		data[STATE_FILE_KEY_V2] = s.toJsonWith(Suspendable.toLegacyDto)
 	}
-	return map[string]string{
-		STATE_FILE_KEY_V2: json,
-		STATE_FILE_KEY_V1: json,
+	if !s.hasIncompatibleTypes(Deplyoment, StatefulSet) {
+		data[STATE_FILE_KEY_V1] = data[STATE_FILE_KEY_V2]
 	}
+	return data
+}
+
+// checkStateFileSize fails if the serialized statefile would not fit into a ConfigMap.
+func checkStateFileSize(data map[string]string) error {
+	size := 0
+	for key, value := range data {
+		size += len(key) + len(value)
+	}
+	if size > STATE_FILE_SIZE_LIMIT {
+		return fmt.Errorf("the suspend state needs %d bytes which exceeds the ConfigMap size limit of %d bytes. Suspend fewer bare Pods", size, STATE_FILE_SIZE_LIMIT)
+	}
+	return nil
 }
 
 func ReadSuspendState(data map[string]string) *SuspendState {
+	if v3, ok := data[STATE_FILE_KEY_V3]; ok && v3 != "" {
+		return newSuspendStateFromJson(v3, suspendableDto.fromDto)
+	}
 	if v2, ok := data[STATE_FILE_KEY_V2]; ok && v2 != "" {
-		return newSuspendStateFromJson(v2)
+		return newSuspendStateFromJson(v2, suspendableDto.fromLegacyDto)
 	}
 	if v1, ok := data[STATE_FILE_KEY_V1]; ok && v1 != "" {
-		return newSuspendStateFromJson(v1)
+		return newSuspendStateFromJson(v1, suspendableDto.fromLegacyDto)
 	}
-	panic(fmt.Errorf("missing both %s and %s in statefile keys in configmap", STATE_FILE_KEY_V1, STATE_FILE_KEY_V2))
+	panic(fmt.Errorf("missing %s, %s and %s in statefile keys in configmap", STATE_FILE_KEY_V1, STATE_FILE_KEY_V2, STATE_FILE_KEY_V3))
 }
 
-func newSuspendStateFromJson(data string) *SuspendState {
+func newSuspendStateFromJson(data string, fromDto func(suspendableDto) Suspendable) *SuspendState {
 	var stateFileDto suspendStateDto
 	err := json.Unmarshal(
 		[]byte(data),
@@ -118,7 +156,7 @@ func newSuspendStateFromJson(data string) *SuspendState {
 
 	suspendables := map[string]Suspendable{}
 	for _, s := range stateFileDto.Suspendables {
-		sus := s.fromDto()
+		sus := fromDto(s)
 		suspendables[sus.Identifier()] = sus
 	}
 	stateFile := SuspendState{
@@ -139,3 +177,45 @@ func (s *SuspendState) SuspendedReplicas() int32 {
 	}
 	return total
 }
+
+// notes returns remarks about individual suspendables worth showing in the status output.
+func (s *SuspendState) notes() []string {
+	var result []string
+	for _, sus := range s.suspendables {
+		if a := sus.Autoscaler(); a != nil {
+			result = append(result, fmt.Sprintf(
+				"%s %s is managed by HorizontalPodAutoscaler %s (%d-%d replicas) and wakes with %d replicas",
+				sus.displayKind(), sus.Name(), a.Name, a.MinReplicas, a.MaxReplicas, sus.WakeReplicas(),
+			))
+		}
+		if o := sus.Owner(); o != nil {
+			ownerKind := schema.FromAPIVersionAndKind(o.APIVersion, o.Kind).GroupKind()
+			if o.Suspended {
+				result = append(result, fmt.Sprintf("%s %s is managed by %s %s, which is suspended instead", sus.displayKind(), sus.Name(), o.Kind, o.Name))
+			} else {
+				result = append(result, fmt.Sprintf(
+					"warning: %s %s is managed by %s %s and keeps running. Add a field rule for %s to suspend it",
+					sus.displayKind(), sus.Name(), o.Kind, o.Name, ownerKind,
+				))
+			}
+		}
+		if sus.manifestType == PostgresCluster && sus.Replicas > 0 {
+			result = append(result, fmt.Sprintf("CloudNativePG Cluster %s is hibernated (%d instances)", sus.Name(), sus.Replicas))
+		}
+	}
+	slices.Sort(result)
+	if loadBalancers := s.count(LoadBalancer); loadBalancers > 0 {
+		result = append(result, fmt.Sprintf("%d load balancers released by switching their Services to ClusterIP", loadBalancers))
+	}
+	return result
+}
+
+func (s *SuspendState) count(manifestType ManifestType) int {
+	var count int
+	for _, sus := range s.suspendables {
+		if sus.manifestType == manifestType {
+			count++
+		}
+	}
+	return count
+}
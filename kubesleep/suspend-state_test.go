@@ -38,14 +38,14 @@ const TEST_SUSPEND_STATE_FILE_JSON = `{
 
 func (s *Unittest) TestStatefileJson() {
 	json := TEST_SUSPEND_STATE_FILE.toJson()
-	stateFile := newSuspendStateFromJson(json)
+	stateFile := newSuspendStateFromJson(json, suspendableDto.fromDto)
 	s.Require().Equal(&TEST_SUSPEND_STATE_FILE, stateFile)
 }
 
 func (s *Unittest) TestEmptyStatefileJson() {
 	expectedStateFile := &SuspendState{suspendables: map[string]Suspendable{}}
 	json := expectedStateFile.toJson()
-	stateFile := newSuspendStateFromJson(json)
+	stateFile := newSuspendStateFromJson(json, suspendableDto.fromDto)
 	s.Require().Equal(expectedStateFile, stateFile)
 }
 
@@ -55,29 +55,29 @@ func (s *Unittest) TestSerializeStatefile() {
 }
 
 func (s *Unittest) TestDeserializeStatefile() {
-	stateFile := newSuspendStateFromJson(TEST_SUSPEND_STATE_FILE_JSON)
+	stateFile := newSuspendStateFromJson(TEST_SUSPEND_STATE_FILE_JSON, suspendableDto.fromDto)
 	s.Require().Equal(&TEST_SUSPEND_STATE_FILE, stateFile)
 }
 
 func (s *Unittest) TestDeserializeStatefileInvalidJson() {
 	s.Require().Panics(func() {
-		_ = newSuspendStateFromJson("{\"")
+		_ = newSuspendStateFromJson("{\"", suspendableDto.fromDto)
 	})
 }
 
 func (s *Unittest) TestDeserializeStatefileIncompleteJson() {
 	s.Require().Panics(func() {
-		_ = newSuspendStateFromJson(`{"finished": false}`)
+		_ = newSuspendStateFromJson(`{"finished": false}`, suspendableDto.fromDto)
 	})
 }
 
 func (s *Unittest) TestMergeStateFiles() {
-	a := Suspendable{1, "a", 1, nil}
-	b := Suspendable{2, "b", 2, nil}
-	c := Suspendable{1, "c", 3, nil}
-	c2 := Suspendable{1, "c", 30, nil}
-	d := Suspendable{2, "d", 4, nil}
-	e := Suspendable{1, "e", 5, nil}
+	a := NewSuspendable(1, "a", 1, nil)
+	b := NewSuspendable(2, "b", 2, nil)
+	c := NewSuspendable(1, "c", 3, nil)
+	c2 := NewSuspendable(1, "c", 30, nil)
+	d := NewSuspendable(2, "d", 4, nil)
+	e := NewSuspendable(1, "e", 5, nil)
 	existing := SuspendState{
 		map[string]Suspendable{
 			a.Identifier(): a,
@@ -111,41 +111,69 @@ func (s *Unittest) TestMergeStateFiles() {
 }
 
 // makeTestStateFile creates a state file that always contains a Deployment "d1"
-// and conditionally includes a CronJob "cj1" when includeCronJob is true.
-func makeTestStateFile(includeCronJob bool) SuspendState {
+// and conditionally includes the suspendables passed as extra.
+func makeTestStateFile(extra ...Suspendable) SuspendState {
 	suspendables := map[string]Suspendable{}
 	d1 := NewSuspendable(Deplyoment, "d1", 1, nil)
 	suspendables[d1.Identifier()] = d1
-	if includeCronJob {
-		cj1 := NewSuspendable(CronJob, "cj1", 1, nil)
-		suspendables[cj1.Identifier()] = cj1
+	for _, sus := range extra {
+		suspendables[sus.Identifier()] = sus
 	}
 	return NewSuspendState(suspendables, false)
 }
 
+var (
+	testCronJob = NewSuspendable(CronJob, "cj1", 0, nil).WithSuspended(false)
+	testJob     = NewSuspendable(Job, "j1", 1, nil).WithSuspended(false)
+)
+
 func (s *Unittest) TestWriteSuspendStateWithoutCronJobs() {
-	state := makeTestStateFile(false)
+	state := makeTestStateFile()
 	data := state.Write()
 
-	// Both v1 and v2 exist and are identical
+	// All versions exist and are identical
 	s.Require().Contains(data, STATE_FILE_KEY_V1)
 	s.Require().Contains(data, STATE_FILE_KEY_V2)
+	s.Require().Contains(data, STATE_FILE_KEY_V3)
 	s.Require().Equal(data[STATE_FILE_KEY_V1], data[STATE_FILE_KEY_V2])
+	s.Require().Equal(data[STATE_FILE_KEY_V2], data[STATE_FILE_KEY_V3])
 }
 
 func (s *Unittest) TestWriteSuspendStateWithCronJobs() {
-	state := makeTestStateFile(true)
+	state := makeTestStateFile(testCronJob)
 	data := state.Write()
 
-	// v2 contains real data; v1 contains an upgrade message
+	// v2 and v3 contain real data; v1 contains an upgrade message
 	s.Require().Contains(data, STATE_FILE_KEY_V1)
 	s.Require().Contains(data, STATE_FILE_KEY_V2)
-	s.Require().NotEqual(data[STATE_FILE_KEY_V1], data[STATE_FILE_KEY_V2])
+	s.Require().Contains(data, STATE_FILE_KEY_V3)
 	s.Require().Contains(data[STATE_FILE_KEY_V1], "please upgrade kubesleep")
+	s.Require().NotContains(data[STATE_FILE_KEY_V2], "Suspended")
+	s.Require().Contains(data[STATE_FILE_KEY_V3], `"Suspended": false`)
+}
+
+func (s *Unittest) TestWriteSuspendStateWithV3Types() {
+	state := makeTestStateFile(testCronJob, testJob)
+	data := state.Write()
+
+	// Only v3 contains real data
+	s.Require().Contains(data[STATE_FILE_KEY_V1], "please upgrade kubesleep")
+	s.Require().Contains(data[STATE_FILE_KEY_V2], "please upgrade kubesleep")
+	s.Require().Equal(&state, ReadSuspendState(data))
+}
+
+func (s *Unittest) TestWriteLegacyCronJobReplicas() {
+	suspended := NewSuspendable(CronJob, "suspended", 0, nil).WithSuspended(true)
+	state := makeTestStateFile(testCronJob, suspended)
+	data := state.Write()
+
+	legacy := newSuspendStateFromJson(data[STATE_FILE_KEY_V2], suspendableDto.fromDto)
+	s.Require().Equal(int32(1), legacy.suspendables[testCronJob.Identifier()].Replicas)
+	s.Require().Equal(int32(0), legacy.suspendables[suspended.Identifier()].Replicas)
 }
 
 func (s *Unittest) TestOldVersionParseFails() {
-	state := makeTestStateFile(true)
+	state := makeTestStateFile(testCronJob)
 	data := state.Write()
 	msgJson := data[STATE_FILE_KEY_V1]
 
@@ -155,21 +183,57 @@ func (s *Unittest) TestOldVersionParseFails() {
 		msgJson,
 		suspendStateDto{},
 	)
-	s.Require().PanicsWithError(expected, func() { _ = newSuspendStateFromJson(msgJson) })
+	s.Require().PanicsWithError(expected, func() { _ = newSuspendStateFromJson(msgJson, suspendableDto.fromLegacyDto) })
+}
+
+func (s *Unittest) TestReadV3() {
+	expectedState := makeTestStateFile(testCronJob)
+	data := expectedState.Write()
+
+	actual := ReadSuspendState(data)
+
+	s.Require().Equal(&expectedState, actual)
 }
 
 func (s *Unittest) TestReadV2() {
-	expectedState := makeTestStateFile(true)
+	expectedState := makeTestStateFile(testCronJob)
 	data := expectedState.Write()
+	delete(data, STATE_FILE_KEY_V3)
 
 	actual := ReadSuspendState(data)
 
 	s.Require().Equal(&expectedState, actual)
 }
 
+func (s *Unittest) TestMigrateV2CronJobs() {
+	data := map[string]string{
+		STATE_FILE_KEY_V2: `{
+  "suspendables": [
+    {"ManifestType": 2, "Name": "running", "Replicas": 1},
+    {"ManifestType": 2, "Name": "suspended", "Replicas": 0},
+    {"ManifestType": 6, "Name": "job", "Replicas": 1}
+  ],
+  "finished": true
+}`,
+	}
+	running := NewSuspendable(CronJob, "running", 0, nil).WithSuspended(false)
+	suspended := NewSuspendable(CronJob, "suspended", 0, nil).WithSuspended(true)
+	job := NewSuspendable(Job, "job", 0, nil).WithSuspended(false)
+	expected := NewSuspendState(map[string]Suspendable{
+		running.Identifier():   running,
+		suspended.Identifier(): suspended,
+		job.Identifier():       job,
+	}, true)
+
+	actual := ReadSuspendState(data)
+
+	s.Require().Equal(&expected, actual)
+}
+
 func (s *Unittest) TestReadV1() {
-	expectedState := makeTestStateFile(false)
+	expectedState := makeTestStateFile()
 	data := expectedState.Write()
+	delete(data, STATE_FILE_KEY_V3)
 	delete(data, STATE_FILE_KEY_V2)
 
 	actual := ReadSuspendState(data)
@@ -183,3 +247,24 @@ func (s *Unittest) TestNewSuspendStateHonorsFinishedFlag() {
 	stFalse := NewSuspendState(map[string]Suspendable{}, false)
 	s.Require().False(stFalse.finished)
 }
+
+func (s *Unittest) TestMergeKeepsDeletedBarePods() {
+	pod := NewSuspendable(BarePod, "debug", 1, nil).WithState([]byte(`{"kind":"Pod"}`))
+	gone := NewSuspendable(Deplyoment, "gone", 1, nil)
+	existing := NewSuspendState(map[string]Suspendable{pod.Identifier(): pod, gone.Identifier(): gone}, true)
+	new := NewSuspendState(map[string]Suspendable{}, false)
+
+	actual := existing.merge(&new)
+
+	s.Require().Equal(&SuspendState{map[string]Suspendable{pod.Identifier(): pod}, false}, actual)
+}
+
+func (s *Unittest) TestMergeKeepsReleasedLoadBalancers() {
+	service := NewSuspendable(LoadBalancer, "web", 0, nil).WithState([]byte(`{"type":"LoadBalancer"}`))
+	existing := NewSuspendState(map[string]Suspendable{service.Identifier(): service}, true)
+	new := NewSuspendState(map[string]Suspendable{}, false)
+
+	actual := existing.merge(&new)
+
+	s.Require().Equal(&SuspendState{map[string]Suspendable{service.Identifier(): service}, false}, actual)
+}
@@ -1,8 +1,14 @@
 package kubesleep
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"maps"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 type ManifestType int
@@ -10,13 +16,182 @@ type ManifestType int
 const (
 	Deplyoment ManifestType = iota
 	StatefulSet
+	// CronJob records whether it was suspended in an explicit boolean instead of its replicas.
 	CronJob
+	// Scalable is any other namespaced resource exposing the /scale subresource.
+	// Its apiVersion and kind are recorded alongside the name.
+	Scalable
+	// RuleBased is a resource suspended by setting a field according to a user supplied FieldRule.
+	// The original field values are recorded in the suspendable's state.
+	RuleBased
+	// DaemonSet is suspended with an unsatisfiable node selector.
+	// The original node selector is recorded in the suspendable's state.
+	DaemonSet
+	// Job is a batch Job that is not owned by a CronJob. It is suspended like a CronJob.
+	Job
+	// ReplicaSet is a ReplicaSet that is not managed by a Deployment or another controller.
+	ReplicaSet
+	// ReplicationController is a ReplicationController that is not managed by a controller.
+	ReplicationController
+	// BarePod is a Pod without ownerReferences. It is deleted on suspend
+	// and recreated on wake from the manifest recorded in the suspendable's state.
+	BarePod
+	// Annotated is a resource of a built-in operator integration suspended by setting an annotation.
+	// The original annotation value is recorded in the suspendable's state.
+	Annotated
+	// Rollout is an Argo Rollout scaled through its /scale subresource.
+	Rollout
+	// ArgoApplication is an Argo CD Application deploying into the namespace. Its automated sync is disabled
+	// while the namespace is suspended. The name has the form <namespace>/<name> because
+	// Applications usually live in another namespace. The original sync policy is recorded in the state.
+	ArgoApplication
+	// FluxResource is a Flux Kustomization or HelmRelease deploying into the namespace. The name has the form
+	// <namespace>/<name>. The suspended flag records whether it was suspended before kubesleep touched it.
+	FluxResource
+	// PostgresCluster is a CloudNativePG Cluster suspended by declarative hibernation. Replicas holds its instances,
+	// the original hibernation annotation is recorded in the state.
+	PostgresCluster
+	// VirtualMachine is a KubeVirt VirtualMachine halted through its run strategy. The original
+	// runStrategy or running field is recorded in the state.
+	VirtualMachine
+	// KafkaNodePool is a Strimzi node pool scaled through its /scale subresource.
+	KafkaNodePool
+	// Kafka is a Strimzi Kafka cluster whose reconciliation is paused. The original pause annotation is recorded in the state.
+	Kafka
+	// DeploymentConfig is an OpenShift DeploymentConfig scaled through its /scale subresource.
+	DeploymentConfig
+	// CronWorkflow is an Argo Workflows CronWorkflow. Like a CronJob, the original suspend flag is recorded
+	// and the running Workflows suspended together with it are kept in the state.
+	CronWorkflow
+	// OperatorManaged records a Deployment, StatefulSet or Job controlled by a custom resource. Its operator would revert
+	// any scaling, so it is never touched. The owner is kept for the status output.
+	OperatorManaged
+	// LoadBalancer is a Service of type LoadBalancer switched to ClusterIP. The load balancer specific fields
+	// are recorded in the state.
+	LoadBalancer
+	// PluginBased is a resource of an out-of-tree suspendable type handled by a kubesleep plugin executable.
+	// The plugin name and its opaque state are recorded in the state.
+	PluginBased
 )
 
+// hasSuspendFlag reports whether the manifest type records its state in the suspended boolean.
+func (t ManifestType) hasSuspendFlag() bool {
+	return t == CronJob || t == Job
+}
+
+func (t ManifestType) String() string {
+	switch t {
+	case Deplyoment:
+		return "Deployment"
+	case StatefulSet:
+		return "StatefulSet"
+	case CronJob:
+		return "CronJob"
+	case Scalable:
+		return "Scalable"
+	case RuleBased:
+		return "RuleBased"
+	case DaemonSet:
+		return "DaemonSet"
+	case Job:
+		return "Job"
+	case ReplicaSet:
+		return "ReplicaSet"
+	case ReplicationController:
+		return "ReplicationController"
+	case BarePod:
+		return "Pod"
+	case Annotated:
+		return "Annotated"
+	case Rollout:
+		return "Rollout"
+	case ArgoApplication:
+		return "Application"
+	case FluxResource:
+		return "FluxResource"
+	case PostgresCluster:
+		return "Cluster"
+	case VirtualMachine:
+		return "VirtualMachine"
+	case KafkaNodePool:
+		return "KafkaNodePool"
+	case Kafka:
+		return "Kafka"
+	case DeploymentConfig:
+		return "DeploymentConfig"
+	case CronWorkflow:
+		return "CronWorkflow"
+	case OperatorManaged:
+		return "OperatorManaged"
+	case LoadBalancer:
+		return "Service"
+	case PluginBased:
+		return "PluginBased"
+	default:
+		return fmt.Sprintf("ManifestType(%d)", int(t))
+	}
+}
+
+// Autoscaler records the HorizontalPodAutoscaler managing the replicas of a suspendable.
+type Autoscaler struct {
+	Name        string
+	MinReplicas int32
+	MaxReplicas int32
+}
+
+// vanishesOnSuspend reports whether a repeated suspend no longer finds suspended resources of the manifest type.
+func (t ManifestType) vanishesOnSuspend() bool {
+	return t == BarePod || t == LoadBalancer
+}
+
+// phase orders the suspension of manifest types. Lower phases are suspended first and woken last.
+// GitOps controllers have to stop reconciling before the workloads they manage are scaled down,
+// databases and operators of stateful services are suspended after and woken before the workloads using them.
+func (t ManifestType) phase() int {
+	switch t {
+	case ArgoApplication, FluxResource:
+		return 0
+	case PostgresCluster, Kafka:
+		return 2
+	default:
+		return 1
+	}
+}
+
+// inPhases groups suspendables by the phase of their manifest type in ascending order.
+func inPhases(suspendables map[string]Suspendable) [][]Suspendable {
+	byPhase := map[int][]Suspendable{}
+	for _, sus := range suspendables {
+		phase := sus.manifestType.phase()
+		byPhase[phase] = append(byPhase[phase], sus)
+	}
+
+	var result [][]Suspendable
+	for _, phase := range slices.Sorted(maps.Keys(byPhase)) {
+		result = append(result, byPhase[phase])
+	}
+	return result
+}
+
+// Owner records the controller of an OperatorManaged workload.
+type Owner struct {
+	APIVersion string
+	Kind       string
+	Name       string
+	// Suspended is true if kubesleep suspends the owner instead of the workload.
+	Suspended bool
+}
+
 type Suspendable struct {
 	manifestType ManifestType
+	apiVersion   string
+	kind         string
 	name         string
 	Replicas     int32
+	suspended    *bool
+	autoscaler   *Autoscaler
+	owner        *Owner
+	state        json.RawMessage
 	Suspend      func(context.Context) error
 }
 
@@ -29,12 +204,80 @@ func NewSuspendable(manifestType ManifestType, name string, Replicas int32, susp
 	}
 }
 
+// WithResource returns a copy of the suspendable that references a resource by apiVersion and kind.
+// This is required for manifest types that are not bound to a single kubernetes kind.
+func (s Suspendable) WithResource(apiVersion, kind string) Suspendable {
+	s.apiVersion = apiVersion
+	s.kind = kind
+	return s
+}
+
+// WithSuspended returns a copy of the suspendable recording whether the resource was suspended
+// before kubesleep touched it. Used by manifest types that are toggled instead of scaled.
+func (s Suspendable) WithSuspended(suspended bool) Suspendable {
+	s.suspended = &suspended
+	return s
+}
+
+// WithAutoscaler returns a copy of the suspendable managed by the given HorizontalPodAutoscaler.
+func (s Suspendable) WithAutoscaler(autoscaler Autoscaler) Suspendable {
+	s.autoscaler = &autoscaler
+	return s
+}
+
+// WithOwner returns a copy of the suspendable controlled by the given owner.
+func (s Suspendable) WithOwner(owner Owner) Suspendable {
+	s.owner = &owner
+	return s
+}
+
+// WithState returns a copy of the suspendable carrying an opaque JSON document.
+// The state is persisted in the statefile and handed back to the K8S implementation on wake.
+func (s Suspendable) WithState(state json.RawMessage) Suspendable {
+	s.state = state
+	return s
+}
+
+func (s Suspendable) ManifestType() ManifestType { return s.manifestType }
+func (s Suspendable) Name() string               { return s.name }
+func (s Suspendable) APIVersion() string         { return s.apiVersion }
+func (s Suspendable) Kind() string               { return s.kind }
+func (s Suspendable) State() json.RawMessage     { return s.state }
+
+func (s Suspendable) Autoscaler() *Autoscaler { return s.autoscaler }
+func (s Suspendable) Owner() *Owner           { return s.owner }
+
+// WakeReplicas returns the replica count to restore on wake.
+// Autoscaled workloads are woken with the minimum replicas of their autoscaler, which then takes over again.
+// Workloads that were already scaled to zero before the suspend stay at zero.
+func (s Suspendable) WakeReplicas() int32 {
+	if s.autoscaler != nil && s.Replicas != 0 {
+		return max(s.autoscaler.MinReplicas, 1)
+	}
+	return s.Replicas
+}
+
+// displayKind returns the kubernetes kind of the suspendable for user facing output.
+func (s Suspendable) displayKind() string {
+	if s.kind != "" {
+		return s.kind
+	}
+	return s.manifestType.String()
+}
+
+// Suspended returns the recorded suspend flag. A missing flag means the resource was running.
+func (s Suspendable) Suspended() bool { return s.suspended != nil && *s.suspended }
+
 func (s Suspendable) Identifier() string {
-	return fmt.Sprintf("%d:%s", s.manifestType, s.name)
+	if s.kind == "" {
+		return fmt.Sprintf("%d:%s", s.manifestType, s.name)
+	}
+	groupKind := schema.FromAPIVersionAndKind(s.apiVersion, s.kind).GroupKind()
+	return fmt.Sprintf("%d:%s:%s", s.manifestType, groupKind, s.name)
 }
 
 func (s Suspendable) wake(ctx context.Context, namespace string, k8s K8S) error {
-	if err := k8s.ScaleSuspendable(ctx, namespace, s.manifestType, s.name, s.Replicas); err != nil {
+	if err := k8s.ScaleSuspendable(ctx, namespace, s); err != nil {
 		return fmt.Errorf("Failed to scale resource: %s of type: %d in Namespace: %s, %w", s.name, s.manifestType, namespace, err)
 	}
 	return nil
@@ -43,21 +286,75 @@ func (s Suspendable) wake(ctx context.Context, namespace string, k8s K8S) error
 func (s Suspendable) toDto() suspendableDto {
 	return suspendableDto{
 		ManifestType: s.manifestType,
+		APIVersion:   s.apiVersion,
+		Kind:         s.kind,
 		Name:         s.name,
 		Replicas:     s.Replicas,
+		Suspended:    s.suspended,
+		Autoscaler:   s.autoscaler,
+		Owner:        s.owner,
+		State:        s.state,
 	}
 }
 
 type suspendableDto struct {
 	ManifestType ManifestType
+	APIVersion   string `json:",omitempty"`
+	Kind         string `json:",omitempty"`
 	Name         string
 	Replicas     int32
+	Suspended    *bool           `json:",omitempty"`
+	Autoscaler   *Autoscaler     `json:",omitempty"`
+	Owner        *Owner          `json:",omitempty"`
+	State        json.RawMessage `json:",omitempty"`
 }
 
 func (s suspendableDto) fromDto() Suspendable {
 	return Suspendable{
 		manifestType: s.ManifestType,
+		apiVersion:   s.APIVersion,
+		kind:         s.Kind,
 		name:         s.Name,
 		Replicas:     s.Replicas,
+		suspended:    s.Suspended,
+		autoscaler:   s.Autoscaler,
+		owner:        s.Owner,
+		state:        compactJson(s.State),
+	}
+}
+
+// fromLegacyDto converts a suspendable of the v1 and v2 statefiles.
+// These encode the suspend flag of CronJobs and Jobs as 0=suspended and 1=running in the replicas.
+func (s suspendableDto) fromLegacyDto() Suspendable {
+	sus := s.fromDto()
+	if sus.manifestType.hasSuspendFlag() && sus.suspended == nil {
+		sus = sus.WithSuspended(sus.Replicas == 0)
+		sus.Replicas = 0
+	}
+	return sus
+}
+
+// toLegacyDto converts a suspendable into the v2 statefile representation.
+func (s Suspendable) toLegacyDto() suspendableDto {
+	dto := s.toDto()
+	if s.manifestType.hasSuspendFlag() {
+		dto.Suspended = nil
+		dto.Replicas = 1
+		if s.Suspended() {
+			dto.Replicas = 0
+		}
+	}
+	return dto
+}
+
+// compactJson undoes the indentation the statefile serialization applies to embedded JSON documents.
+func compactJson(data json.RawMessage) json.RawMessage {
+	if len(data) == 0 {
+		return nil
+	}
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, data); err != nil {
+		panic(fmt.Errorf("failed to compact suspendable state %s: %w", data, err))
 	}
+	return buf.Bytes()
 }
@@ -18,7 +18,7 @@ var TEST_SUSPENDABLES = map[string]Suspendable{
 func (s *Unittest) TestScaleStatefulSetBrokenK8S() {
 	k8s, _ := NewMockK8S()
 	sus := NewSuspendable(StatefulSet, "test-statefulset", int32(2), nil)
-	k8s.On("ScaleSuspendable", mock.Anything, "foo", StatefulSet, "test-statefulset", int32(2)).Return(errExpected)
+	k8s.On("ScaleSuspendable", mock.Anything, "foo", sus).Return(errExpected)
 
 	err := sus.wake(context.TODO(), "foo", k8s)
 
@@ -29,10 +29,71 @@ func (s *Unittest) TestScaleStatefulSetBrokenK8S() {
 func (s *Unittest) TestScaleStatefulSet() {
 	k8s, _ := NewMockK8S()
 	sus := NewSuspendable(StatefulSet, "test-statefulset", int32(2), nil)
-	k8s.On("ScaleSuspendable", mock.Anything, "foo", StatefulSet, "test-statefulset", int32(2)).Return(nil)
+	k8s.On("ScaleSuspendable", mock.Anything, "foo", sus).Return(nil)
 
 	err := sus.wake(context.TODO(), "foo", k8s)
 
 	k8s.AssertExpectations(s.T())
 	s.Require().NoError(err)
 }
+
+func (s *Unittest) TestScalableIdentifierIncludesGroupKind() {
+	cloneSet := NewSuspendable(Scalable, "foo", int32(2), nil).WithResource("apps.kruise.io/v1alpha1", "CloneSet")
+	prometheus := NewSuspendable(Scalable, "foo", int32(1), nil).WithResource("monitoring.coreos.com/v1", "Prometheus")
+
+	s.Require().Equal("3:CloneSet.apps.kruise.io:foo", cloneSet.Identifier())
+	s.Require().NotEqual(cloneSet.Identifier(), prometheus.Identifier())
+}
+
+func (s *Unittest) TestScalableDtoRoundTrip() {
+	sus := NewSuspendable(Scalable, "foo", int32(2), nil).WithResource("apps.kruise.io/v1alpha1", "CloneSet")
+
+	s.Require().Equal(sus, sus.toDto().fromDto())
+}
+
+func (s *Unittest) TestSuspendableStateSurvivesStatefile() {
+	sus := NewSuspendable(RuleBased, "vm", int32(1), nil).
+		WithResource("kubevirt.io/v1", "VirtualMachine").
+		WithState([]byte(`[{"path":".spec.runStrategy","value":"Always"}]`))
+	state := NewSuspendState(map[string]Suspendable{sus.Identifier(): sus}, true)
+
+	actual := ReadSuspendState(state.Write())
+
+	s.Require().Equal(&state, actual)
+}
+
+func (s *Unittest) TestWakeReplicasOfAutoscaledSuspendable() {
+	sus := NewSuspendable(Deplyoment, "web", int32(7), nil)
+	s.Require().Equal(int32(7), sus.WakeReplicas())
+
+	autoscaled := sus.WithAutoscaler(Autoscaler{Name: "web", MinReplicas: 2, MaxReplicas: 10})
+	s.Require().Equal(int32(2), autoscaled.WakeReplicas())
+
+	scaleToZero := sus.WithAutoscaler(Autoscaler{Name: "web", MinReplicas: 0, MaxReplicas: 10})
+	s.Require().Equal(int32(1), scaleToZero.WakeReplicas())
+}
+
+func (s *Unittest) TestWakeReplicasOfAutoscaledSuspendableScaledToZero() {
+	sus := NewSuspendable(Deplyoment, "web", int32(0), nil).WithAutoscaler(Autoscaler{Name: "web", MinReplicas: 2, MaxReplicas: 10})
+	s.Require().Equal(int32(0), sus.WakeReplicas())
+}
+
+func (s *Unittest) TestAutoscalerSurvivesStatefile() {
+	sus := NewSuspendable(Deplyoment, "web", int32(7), nil).WithAutoscaler(Autoscaler{Name: "web-hpa", MinReplicas: 2, MaxReplicas: 10})
+	state := NewSuspendState(map[string]Suspendable{sus.Identifier(): sus}, true)
+
+	actual := ReadSuspendState(state.Write())
+
+	s.Require().Equal(&state, actual)
+}
+
+func (s *Unittest) TestOwnerSurvivesStatefile() {
+	sus := NewSuspendable(OperatorManaged, "web", 0, nil).
+		WithResource("apps/v1", "Deployment").
+		WithOwner(Owner{APIVersion: "example.com/v1", Kind: "App", Name: "web", Suspended: true})
+	state := NewSuspendState(map[string]Suspendable{sus.Identifier(): sus}, true)
+
+	actual := ReadSuspendState(state.Write())
+
+	s.Require().Equal(&state, actual)
+}